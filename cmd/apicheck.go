@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/dterbah/zendoc/internal/apicheck"
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/generate"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var apicheckBaseline string
+var apicheckRef string
+var apicheckBaselineVersion string
+var apicheckJSON bool
+
+var apicheckZenDoc = &cobra.Command{
+	Use:   "apicheck",
+	Short: "Detect the breaking changes of the API since a baseline, and check the version is bumped accordingly",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if (apicheckBaseline == "") == (apicheckRef == "") {
+			color.Red("Expected either a baseline (--baseline) or a git ref (--ref)")
+			cmd.Usage()
+			os.Exit(1)
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		projectConfig, err := generate.LoadConfiguration(cmd.Context(), "")
+		if err != nil {
+			color.Red("%s", err)
+			os.Exit(1)
+		}
+
+		var baseline *doc.ProjectDoc
+		baselineVersion := apicheckBaselineVersion
+		if apicheckRef != "" {
+			var refVersion string
+			baseline, refVersion, err = generate.ParseRefAPI(cmd.Context(), apicheckRef)
+			if baselineVersion == "" {
+				baselineVersion = refVersion
+			}
+		} else {
+			baseline, err = generate.LoadDocumentation(apicheckBaseline)
+			if _, statErr := os.Stat(apicheckBaseline); statErr != nil && baselineVersion == "" {
+				// the baseline is a version exported by the web output
+				baselineVersion = apicheckBaseline
+			}
+		}
+		if err != nil {
+			color.Red("%s", err)
+			os.Exit(1)
+		}
+
+		current, err := apicheck.ParseAPI(cmd.Context(), os.DirFS("."), ".")
+		if err != nil {
+			color.Red("error when parsing your project %s", err)
+			os.Exit(1)
+		}
+
+		report := apicheck.Check(*baseline, *current)
+		if apicheckJSON {
+			err = apicheck.WriteJSON(color.Output, report)
+		} else {
+			err = apicheck.WriteText(color.Output, report)
+		}
+		if err != nil {
+			color.Red("error when writing the report %s", err)
+			os.Exit(1)
+		}
+
+		if !report.HasBreakingChanges() {
+			return
+		}
+
+		if baselineVersion == "" {
+			color.Red("Breaking changes found, and the version of the baseline is unknown: set it with --baseline-version")
+			os.Exit(1)
+		}
+
+		if err := report.CheckVersion(baselineVersion, projectConfig.ProjectConfig.Version); err != nil {
+			color.Red("%s", err)
+			os.Exit(1)
+		}
+
+		color.Green("Breaking changes allowed by the version bump from %s to %s", baselineVersion, projectConfig.ProjectConfig.Version)
+	},
+}
+
+func init() {
+	apicheckZenDoc.Flags().StringVar(&apicheckBaseline, "baseline", "", "Path of a doc.json file, or a version exported by the web output")
+	apicheckZenDoc.Flags().StringVar(&apicheckRef, "ref", "", "Git ref (tag, branch or commit) whose code is the baseline")
+	apicheckZenDoc.Flags().StringVar(&apicheckBaselineVersion, "baseline-version", "", "Version of the baseline, when it cannot be deduced")
+	apicheckZenDoc.Flags().BoolVar(&apicheckJSON, "json", false, "Print the report as JSON")
+	rootCmd.AddCommand(apicheckZenDoc)
+}
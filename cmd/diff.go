@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/diff"
+	"github.com/dterbah/zendoc/internal/doc/generate"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var diffFormat string
+
+var diffZenDoc = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Compare the API of two documentation versions or JSON files",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			color.Red("Expected two versions or documentation files to compare")
+			cmd.Usage()
+			os.Exit(1)
+		}
+
+		if !slices.Contains(diff.FORMATS, diffFormat) {
+			color.Red("Invalid format. Must be one of: %s", strings.Join(diff.FORMATS, ", "))
+			cmd.Usage()
+			os.Exit(1)
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		oldDoc, err := generate.LoadDocumentation(args[0])
+		if err != nil {
+			color.Red("%s", err)
+			os.Exit(1)
+		}
+
+		newDoc, err := generate.LoadDocumentation(args[1])
+		if err != nil {
+			color.Red("%s", err)
+			os.Exit(1)
+		}
+
+		report := diff.Compare(args[0], *oldDoc, args[1], *newDoc)
+		if err := diff.Write(color.Output, report, diffFormat); err != nil {
+			color.Red("error when writing the diff %s", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	diffZenDoc.Flags().StringVarP(&diffFormat, "format", "f", diff.TEXT_FORMAT, "Output format: "+strings.Join(diff.FORMATS, ", "))
+	rootCmd.AddCommand(diffZenDoc)
+}
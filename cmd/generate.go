@@ -2,28 +2,74 @@ package cmd
 
 import (
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/dterbah/zendoc/internal"
 	"github.com/dterbah/zendoc/internal/doc/generate"
+	"github.com/dterbah/zendoc/internal/export"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var watch bool
+var plugin string
+var changelog bool
+var version string
+var ref string
+var allTags bool
+var output string
+var gzipOutput bool
+var compact bool
 
 var generateZenDoc = &cobra.Command{
 	Use:   "generate [output]",
 	Short: "Generate doc for the current go project",
 	Args: func(cmd *cobra.Command, args []string) error {
+		if plugin != "" {
+			if len(args) > 0 {
+				color.Red("The output format cannot be used with a plugin")
+				cmd.Usage()
+				os.Exit(1)
+			}
+			if output != "" || gzipOutput || compact {
+				color.Red("The --output, --gzip and --compact flags cannot be used with a plugin")
+				cmd.Usage()
+				os.Exit(1)
+			}
+			return nil
+		}
+
 		if len(args) < 1 {
-			color.Red("Missing output format. Expected 'json' or 'web'")
+			color.Red("Missing output format. Expected one of: %s", strings.Join(internal.EXPORT_TYPES, ", "))
 			cmd.Usage()
 			os.Exit(1)
 		}
 
 		format := args[0]
-		if format != internal.JSON_EXPORT_TYPE && format != internal.WEB_EXPORT_TYPE {
-			color.Red("Invalid output format. Must be '%s' or '%s'", internal.WEB_EXPORT_TYPE, internal.JSON_EXPORT_TYPE)
+		if !slices.Contains(internal.EXPORT_TYPES, format) {
+			color.Red("Invalid output format. Must be one of: %s", strings.Join(internal.EXPORT_TYPES, ", "))
+			cmd.Usage()
+			os.Exit(1)
+		}
+
+		if format != internal.JSON_EXPORT_TYPE && format != internal.JSONL_EXPORT_TYPE && format != internal.SQLITE_EXPORT_TYPE && output != "" {
+			color.Red("The --output flag can only be used with the json, jsonl and sqlite outputs")
+			cmd.Usage()
+			os.Exit(1)
+		}
+		if format == internal.SQLITE_EXPORT_TYPE && output == export.STDOUT_OUTPUT {
+			color.Red("The sqlite output cannot be written on the standard output")
+			cmd.Usage()
+			os.Exit(1)
+		}
+		if format != internal.JSON_EXPORT_TYPE && format != internal.JSONL_EXPORT_TYPE && gzipOutput {
+			color.Red("The --gzip flag can only be used with the json and jsonl outputs")
+			cmd.Usage()
+			os.Exit(1)
+		}
+		if format != internal.JSON_EXPORT_TYPE && compact {
+			color.Red("The --compact flag can only be used with the json output")
 			cmd.Usage()
 			os.Exit(1)
 		}
@@ -31,8 +77,25 @@ var generateZenDoc = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		outputFormat := args[0]
-		err := generate.GenerateDoc(outputFormat, watch)
+		options := generate.GenerateOptions{
+			Plugin:    plugin,
+			Watch:     watch,
+			Changelog: changelog,
+			Version:   version,
+			Ref:       ref,
+			AllTags:   allTags,
+			Output:    output,
+			Gzip:      gzipOutput,
+			Compact:   compact,
+		}
+		if output == export.STDOUT_OUTPUT {
+			// the standard output only receives the documentation
+			color.Output = os.Stderr
+		}
+		if len(args) > 0 {
+			options.OutputFormat = args[0]
+		}
+		err := generate.GenerateDoc(cmd.Context(), options)
 
 		if err != nil {
 			color.Red("error when generating doc %s", err)
@@ -45,5 +108,13 @@ var generateZenDoc = &cobra.Command{
 
 func init() {
 	generateZenDoc.Flags().BoolVarP(&watch, "watch", "w", false, "Watch for file changes and regenerate doc")
+	generateZenDoc.Flags().StringVar(&plugin, "plugin", "", "Export the doc with an external plugin executable found in the PATH")
+	generateZenDoc.Flags().BoolVar(&changelog, "changelog", false, "With the web output, write the changelog of the version compared with the previous one")
+	generateZenDoc.Flags().StringVar(&version, "version", "", "Version of the documentation, overriding the configured one. Use \"auto\" to resolve it from the git tags")
+	generateZenDoc.Flags().StringVar(&ref, "ref", "", "Generate the doc of a git revision (tag, branch or commit) without checking it out")
+	generateZenDoc.Flags().BoolVar(&allTags, "all-tags", false, "Generate the doc of every release tag")
+	generateZenDoc.Flags().StringVarP(&output, "output", "o", "", "With the json, jsonl and sqlite outputs, path of the file, or \"-\" to write the json and jsonl outputs on the standard output")
+	generateZenDoc.Flags().BoolVar(&gzipOutput, "gzip", false, "With the json and jsonl outputs, compress the documentation with gzip")
+	generateZenDoc.Flags().BoolVar(&compact, "compact", false, "With the json output, write the JSON on a single line")
 	rootCmd.AddCommand(generateZenDoc)
 }
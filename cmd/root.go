@@ -1,23 +1,43 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/dterbah/zendoc/internal"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var commandTimeout time.Duration
+
 var rootCmd = &cobra.Command{
 	Use:   "zendoc",
 	Short: "A CLI tool to generate and view documentation of Go project",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cmd.SetContext(system.WithCommandTimeout(cmd.Context(), commandTimeout))
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 
 	},
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Errorf("error when executing cli %s", err)
+	// Ctrl-C and SIGTERM cancel the context, so the commands stop cleanly instead of being killed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		color.Red("error when executing cli %s", err)
+		stop()
 		os.Exit(1)
 	}
 }
+
+func init() {
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "command-timeout", internal.DEFAULT_COMMAND_TIMEOUT, "Maximum duration of an external command, such as git, npm or a plugin. 0 disables the timeout")
+}
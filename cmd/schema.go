@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/dterbah/zendoc/internal/doc/schema"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var schemaOutput string
+
+var schemaZenDoc = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the exported documentation",
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := schema.GenerateJSON()
+		if err != nil {
+			color.Red("error when generating the schema %s", err)
+			os.Exit(1)
+		}
+
+		if schemaOutput == "" {
+			os.Stdout.Write(content)
+			return
+		}
+
+		err = system.OSFileSystem{}.WriteFile(schemaOutput, content, 0644)
+		if err != nil {
+			color.Red("error when saving the schema %s", err)
+			os.Exit(1)
+		}
+
+		color.Green("Schema saved in %s !", schemaOutput)
+	},
+}
+
+func init() {
+	schemaZenDoc.Flags().StringVarP(&schemaOutput, "output", "o", "", "Write the schema into a file instead of stdout")
+	rootCmd.AddCommand(schemaZenDoc)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc/generate"
+	"github.com/dterbah/zendoc/internal/search"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var searchLimit int
+
+var searchZenDoc = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the documented symbols of the current go project",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectDoc, err := generate.ParseProject(cmd.Context())
+		if err != nil {
+			color.Red("error when parsing your project %s", err)
+			os.Exit(1)
+		}
+
+		hits := search.Build(*projectDoc).Search(strings.Join(args, " "), searchLimit)
+		if len(hits) == 0 {
+			color.HiYellow("No symbol found")
+			return
+		}
+
+		for _, hit := range hits {
+			location := fmt.Sprintf("%s:%d", hit.Document.File, hit.Document.Line)
+			fmt.Fprintf(color.Output, "%s  %s %s\n", color.CyanString(location), color.New(color.Bold).Sprint(hit.Document.ID), color.HiBlackString("(%s)", hit.Document.Kind))
+			if hit.Document.Description != "" {
+				fmt.Fprintf(color.Output, "    %s\n", hit.Document.Description)
+			}
+		}
+	},
+}
+
+func init() {
+	searchZenDoc.Flags().IntVarP(&searchLimit, "limit", "n", 20, "Maximum number of results, 0 for no limit")
+	rootCmd.AddCommand(searchZenDoc)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/dterbah/zendoc/internal/doc/generate"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var serveHost string
+var servePort int
+
+var serveZenDoc = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the documentation of the current go project with live reload",
+	Run: func(cmd *cobra.Command, args []string) {
+		address := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+		err := generate.ServeDoc(cmd.Context(), address)
+
+		if err != nil {
+			color.Red("error when serving doc %s", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	serveZenDoc.Flags().StringVar(&serveHost, "host", "localhost", "Host the server listens on")
+	serveZenDoc.Flags().IntVarP(&servePort, "port", "p", 8080, "Port the server listens on")
+	rootCmd.AddCommand(serveZenDoc)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc/generate"
+	"github.com/dterbah/zendoc/internal/viewer"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var showJSON bool
+
+var showZenDoc = &cobra.Command{
+	Use:   "show <symbol>",
+	Short: "Print the documentation of a symbol of the current go project",
+	Long:  "Print the documentation of a symbol, given by its identifier (parser.DocParser.ParseDocForDir), its qualified name (DocParser.ParseDocForDir), its name or any search query",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectDoc, err := generate.ParseProject(cmd.Context())
+		if err != nil {
+			color.Red("error when parsing your project %s", err)
+			os.Exit(1)
+		}
+
+		symbol, err := viewer.Find(*projectDoc, strings.Join(args, " "))
+		if err != nil {
+			var ambiguous viewer.AmbiguousError
+			if errors.As(err, &ambiguous) {
+				color.HiYellow("Several symbols match \"%s\":", ambiguous.Query)
+				for _, candidate := range ambiguous.Candidates {
+					color.HiYellow("  %s", candidate.ID)
+				}
+			} else {
+				color.Red("%s", err)
+			}
+			os.Exit(1)
+		}
+
+		if showJSON {
+			if err := viewer.PrintJSON(os.Stdout, symbol); err != nil {
+				color.Red("error when printing the symbol %s", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		viewer.Print(color.Output, symbol)
+	},
+}
+
+func init() {
+	showZenDoc.Flags().BoolVar(&showJSON, "json", false, "Print the documentation as JSON")
+	rootCmd.AddCommand(showZenDoc)
+}
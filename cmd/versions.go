@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/dterbah/zendoc/config"
+	"github.com/dterbah/zendoc/internal/export"
+	"github.com/dterbah/zendoc/internal/export/app"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var pruneKeep int
+
+var versionsZenDoc = &cobra.Command{
+	Use:   "versions",
+	Short: "Manage the versions of the web documentation",
+}
+
+var versionsListZenDoc = &cobra.Command{
+	Use:   "list",
+	Short: "List the versions of the web documentation, the newest first",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		appConfig, err := app.LoadAppConfig(system.OSFileSystem{}, filepath.Join(webAssetsDir(), app.APP_CONFIG_FILE))
+		if err != nil {
+			color.Red("%s", err)
+			os.Exit(1)
+		}
+
+		versions := slices.Clone(appConfig.Versions)
+		slices.Reverse(versions)
+
+		writer := tabwriter.NewWriter(color.Output, 0, 4, 2, ' ', 0)
+		for _, entry := range versions {
+			channel := ""
+			switch entry.Version {
+			case appConfig.Latest:
+				channel = "latest"
+			case appConfig.Next:
+				channel = "next"
+			}
+
+			revision := entry.Tag
+			if revision == "" && len(entry.Commit) >= 7 {
+				revision = entry.Commit[:7]
+			}
+
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d/%d documented\n", entry.Version, channel, entry.GeneratedAt, revision, entry.DocumentedSymbols, entry.Symbols)
+		}
+		writer.Flush()
+	},
+}
+
+var versionsRemoveZenDoc = &cobra.Command{
+	Use:   "remove <version>",
+	Short: "Remove a version and its documentation files",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnError(app.RemoveVersion(system.OSFileSystem{}, webAssetsDir(), args[0]))
+		color.Green("Version %s removed", args[0])
+	},
+}
+
+var versionsRenameZenDoc = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a version and its documentation files",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnError(app.RenameVersion(system.OSFileSystem{}, webAssetsDir(), args[0], args[1]))
+		color.Green("Version %s renamed to %s", args[0], args[1])
+	},
+}
+
+var versionsSetLatestZenDoc = &cobra.Command{
+	Use:   "set-latest <version>",
+	Short: "Designate the version shown by default",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnError(app.SetLatestVersion(system.OSFileSystem{}, webAssetsDir(), args[0]))
+		color.Green("Version %s is the latest version", args[0])
+	},
+}
+
+var versionsSetNextZenDoc = &cobra.Command{
+	Use:   "set-next [version]",
+	Short: "Designate the version of the next/dev channel, or remove the channel without version",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		version := ""
+		if len(args) > 0 {
+			version = args[0]
+		}
+
+		exitOnError(app.SetNextVersion(system.OSFileSystem{}, webAssetsDir(), version))
+		if version == "" {
+			color.Green("Next channel removed")
+		} else {
+			color.Green("Version %s is the next version", version)
+		}
+	},
+}
+
+var versionsPruneZenDoc = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove the oldest versions, keeping the --keep highest ones",
+	Long:  "Remove the oldest semantic versions and their files, keeping --keep of them. The latest and next versions are always kept and count in --keep, the highest versions fill the remaining places. The versions that are not semantic, such as a branch name, are never removed and are not counted.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		removed, err := app.PruneVersions(system.OSFileSystem{}, webAssetsDir(), pruneKeep)
+		exitOnError(err)
+
+		for _, version := range removed {
+			color.Green("Version %s removed", version)
+		}
+		color.Green("%d version(s) removed", len(removed))
+	},
+}
+
+// webAssetsDir returns the directory of the web documentation holding app.json
+func webAssetsDir() string {
+	projectConfig, err := config.GetConfiguration()
+	if err != nil {
+		color.Red("error when reading the zendoc configuration : %s", err)
+		os.Exit(1)
+	}
+
+	return export.WebAssetsDir(filepath.Join(projectConfig.ProjectConfig.DocPath, projectConfig.ProjectConfig.Name))
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		color.Red("%s", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	versionsPruneZenDoc.Flags().IntVar(&pruneKeep, "keep", 5, "Number of semantic versions to keep, latest and next included")
+	versionsZenDoc.AddCommand(versionsListZenDoc, versionsRemoveZenDoc, versionsRenameZenDoc, versionsSetLatestZenDoc, versionsSetNextZenDoc, versionsPruneZenDoc)
+	rootCmd.AddCommand(versionsZenDoc)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 
 	"github.com/dterbah/zendoc/internal/system"
@@ -11,12 +12,15 @@ import (
 const ZENDOC_CONFIG_FILE = ".zendoc.config.json"
 
 type ProjectConfig struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Version     string `json:"version"`
-	GitLink     string `json:"gitLink"`
-	MainBranch  string `json:"mainBranch"`
-	DocPath     string `json:"docPath"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Version      string `json:"version"`
+	TagPrefix    string `json:"tagPrefix,omitempty"`
+	GitLink      string `json:"gitLink"`
+	GitProvider  string `json:"gitProvider,omitempty"`
+	MainBranch   string `json:"mainBranch"`
+	LinkToCommit bool   `json:"linkToCommit,omitempty"`
+	DocPath      string `json:"docPath"`
 }
 
 type DocConfig struct {
@@ -26,9 +30,16 @@ type DocConfig struct {
 	ExcludeFiles   []string `json:"excludeFiles"`
 }
 
+type TemplateConfig struct {
+	Dir       string `json:"dir"`
+	OutputDir string `json:"outputDir"`
+	Engine    string `json:"engine"`
+}
+
 type Config struct {
-	ProjectConfig ProjectConfig `json:"projectConfig"`
-	DocConfig     DocConfig     `json:"docConfig"`
+	ProjectConfig  ProjectConfig  `json:"projectConfig"`
+	DocConfig      DocConfig      `json:"docConfig"`
+	TemplateConfig TemplateConfig `json:"templateConfig"`
 }
 
 /*
@@ -38,9 +49,20 @@ type Config struct {
 @author Dorian TERBAH
 */
 func GetConfiguration() (*Config, error) {
+	return LoadConfiguration(os.DirFS("."))
+}
+
+/*
+@description Load the ZenDoc configuration from the configuration file at the root of a file system
+@param fsys fs.FS - The file system of the project, such as an extracted archive or an in-memory file system
+@return (*Config, error) - A pointer to the loaded configuration and an error if loading fails
+@example LoadConfiguration(os.DirFS("./myproject")) => &Config{...}, nil
+@author Dorian TERBAH
+*/
+func LoadConfiguration(fsys fs.FS) (*Config, error) {
 	config := Config{}
 
-	fileBytes, err := os.ReadFile(ZENDOC_CONFIG_FILE)
+	fileBytes, err := fs.ReadFile(fsys, ZENDOC_CONFIG_FILE)
 	if err != nil {
 		return nil, fmt.Errorf("error when reading the config file %s", err)
 	}
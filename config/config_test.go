@@ -2,9 +2,12 @@ package config
 
 import (
 	"encoding/json"
+	"io"
+	"io/fs"
 	"os"
 	"testing"
 
+	"github.com/dterbah/zendoc/internal/system"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,11 +21,29 @@ func (m *MockFileSystem) FileExists(path string) bool {
 	return args.Bool(0)
 }
 
+func (m *MockFileSystem) ReadFile(path string) ([]byte, error) {
+	args := m.Called(path)
+	content, _ := args.Get(0).([]byte)
+	return content, args.Error(1)
+}
+
+func (m *MockFileSystem) Open(path string) (fs.File, error) {
+	args := m.Called(path)
+	file, _ := args.Get(0).(fs.File)
+	return file, args.Error(1)
+}
+
 func (m *MockFileSystem) WriteFile(filename string, content []byte, perm uint32) error {
 	args := m.Called(filename, content, perm)
 	return args.Error(0)
 }
 
+func (m *MockFileSystem) Create(path string, perm uint32) (io.WriteCloser, error) {
+	args := m.Called(path, perm)
+	writer, _ := args.Get(0).(io.WriteCloser)
+	return writer, args.Error(1)
+}
+
 func (m *MockFileSystem) MkdirAll(path string, perm os.FileMode) error {
 	args := m.Called(path, perm)
 	return args.Error(0)
@@ -33,6 +54,11 @@ func (m *MockFileSystem) Rename(oldPath, newPath string) error {
 	return args.Error(0)
 }
 
+func (m *MockFileSystem) RemoveAll(path string) error {
+	args := m.Called(path)
+	return args.Error(0)
+}
+
 func TestGetConfiguration_Success(t *testing.T) {
 	expectedConfig := &Config{
 		ProjectConfig: ProjectConfig{
@@ -64,6 +90,20 @@ func TestGetConfiguration_Success(t *testing.T) {
 	assert.Equal(t, expectedConfig, config)
 }
 
+func TestLoadConfiguration(t *testing.T) {
+	fsys := system.NewMemoryFileSystem()
+	assert.NoError(t, fsys.WriteFile(ZENDOC_CONFIG_FILE, []byte(`{"projectConfig": {"name": "memory", "version": "2.0.0"}}`), 0644))
+
+	config, err := LoadConfiguration(fsys)
+	assert.NoError(t, err)
+	assert.Equal(t, "memory", config.ProjectConfig.Name)
+	assert.Equal(t, "2.0.0", config.ProjectConfig.Version)
+
+	config, err = LoadConfiguration(system.NewMemoryFileSystem())
+	assert.Error(t, err)
+	assert.Nil(t, config)
+}
+
 func TestGetConfiguration_FileNotFound(t *testing.T) {
 	os.Remove(ZENDOC_CONFIG_FILE)
 	config, err := GetConfiguration()
@@ -0,0 +1,296 @@
+package apicheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/semver"
+)
+
+/*
+@description Struct to represent a change of the public API
+@author Dorian TERBAH
+@field ID string - The identifier of the changed symbol
+@field Kind string - The kind of the symbol
+@field Description string - What changed
+@field Breaking bool - true if the change can break the code using the API
+*/
+type Change struct {
+	ID          string `json:"id"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Breaking    bool   `json:"breaking"`
+}
+
+/*
+@description Struct to represent the result of an API check
+@author Dorian TERBAH
+@field Breaking []Change - The breaking changes: removed symbols, changed signatures, removed or retyped fields, changed interfaces
+@field Compatible []Change - The compatible changes: added symbols and fields
+*/
+type Report struct {
+	Breaking   []Change `json:"breaking"`
+	Compatible []Change `json:"compatible"`
+}
+
+/*
+@description Compare the exported API of two documentations. Signatures are compared with the declarations read from the source code; when one of the documentations has been generated before they were recorded, the documentation tags are used instead.
+@param baseline doc.ProjectDoc - The documentation of the previous release
+@param current doc.ProjectDoc - The documentation of the current code
+@return Report - The breaking and compatible changes, sorted by symbol identifier
+@author Dorian TERBAH
+*/
+func Check(baseline doc.ProjectDoc, current doc.ProjectDoc) Report {
+	report := Report{Breaking: []Change{}, Compatible: []Change{}}
+
+	oldSymbols := exportedSymbols(baseline)
+	newSymbols := exportedSymbols(current)
+
+	newByID := map[string]doc.Symbol{}
+	for _, symbol := range newSymbols {
+		newByID[symbol.ID] = symbol
+	}
+	oldByID := map[string]doc.Symbol{}
+	for _, symbol := range oldSymbols {
+		oldByID[symbol.ID] = symbol
+		if _, ok := newByID[symbol.ID]; !ok {
+			report.add(symbol, fmt.Sprintf("%s removed", symbol.Item.GetBaseDoc().Type), true)
+		}
+	}
+
+	for _, symbol := range newSymbols {
+		oldSymbol, ok := oldByID[symbol.ID]
+		if !ok {
+			report.add(symbol, fmt.Sprintf("%s added", symbol.Item.GetBaseDoc().Type), false)
+			continue
+		}
+		compareSymbols(&report, oldSymbol, symbol)
+	}
+
+	for _, changes := range [][]Change{report.Breaking, report.Compatible} {
+		sort.SliceStable(changes, func(i, j int) bool {
+			return changes[i].ID < changes[j].ID
+		})
+	}
+
+	return report
+}
+
+/*
+@description Check if the report contains breaking changes
+@return bool - true if at least one change is breaking
+@author Dorian TERBAH
+*/
+func (report Report) HasBreakingChanges() bool {
+	return len(report.Breaking) > 0
+}
+
+/*
+@description Check that the version of the current code is bumped enough for its changes: breaking changes need a new major version, or a new minor version while the major version is 0
+@param baselineVersion string - The version of the baseline
+@param currentVersion string - The version of the current code, usually ProjectConfig.Version
+@return error - An error if a version is not a semantic version, or if breaking changes ship without the required bump
+@example report.CheckVersion("1.4.0", "1.5.0") => error "breaking changes require a major version bump..."
+@author Dorian TERBAH
+*/
+func (report Report) CheckVersion(baselineVersion, currentVersion string) error {
+	baseline, err := semver.Parse(baselineVersion)
+	if err != nil {
+		return fmt.Errorf("invalid baseline version: %w", err)
+	}
+	current, err := semver.Parse(currentVersion)
+	if err != nil {
+		return fmt.Errorf("invalid current version: %w", err)
+	}
+
+	if !report.HasBreakingChanges() {
+		return nil
+	}
+
+	if baseline.Major == 0 {
+		if current.Major > 0 || current.Minor > baseline.Minor {
+			return nil
+		}
+		return fmt.Errorf("%d breaking change(s) require at least version %d.%d.0, but the version is %s", len(report.Breaking), baseline.Major, baseline.Minor+1, currentVersion)
+	}
+
+	if current.Major > baseline.Major {
+		return nil
+	}
+	return fmt.Errorf("%d breaking change(s) require a major version bump from %s (at least %d.0.0), but the version is %s", len(report.Breaking), baselineVersion, baseline.Major+1, currentVersion)
+}
+
+func (report *Report) add(symbol doc.Symbol, description string, breaking bool) {
+	change := Change{
+		ID:          symbol.ID,
+		Kind:        symbol.Item.GetBaseDoc().Type,
+		Description: description,
+		Breaking:    breaking,
+	}
+
+	if breaking {
+		report.Breaking = append(report.Breaking, change)
+	} else {
+		report.Compatible = append(report.Compatible, change)
+	}
+}
+
+// exportedSymbols keeps the symbols reachable from other modules, which a documentation may list with the internal and main packages. Interface methods are checked with their interface.
+func exportedSymbols(projectDoc doc.ProjectDoc) []doc.Symbol {
+	symbols := []doc.Symbol{}
+	for _, symbol := range doc.Symbols(projectDoc) {
+		if symbol.Item.GetBaseDoc().Type == doc.INTERFACE_METHOD_TYPE || symbol.Package == "main" || isInternalPath(symbol.File.Path) {
+			continue
+		}
+
+		exported := true
+		for _, part := range strings.Split(symbol.Name, ".") {
+			exported = exported && token.IsExported(part)
+		}
+		if exported {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+func compareSymbols(report *Report, oldSymbol, newSymbol doc.Symbol) {
+	oldKind, newKind := oldSymbol.Item.GetBaseDoc().Type, newSymbol.Item.GetBaseDoc().Type
+	if oldKind != newKind {
+		report.add(newSymbol, fmt.Sprintf("kind changed from %s to %s", oldKind, newKind), true)
+		return
+	}
+
+	useSource := oldSymbol.Item.GetBaseDoc().Signature != "" && newSymbol.Item.GetBaseDoc().Signature != ""
+	oldShape, newShape := shapeOf(oldSymbol.Item, useSource), shapeOf(newSymbol.Item, useSource)
+
+	if oldShape.signature != newShape.signature {
+		report.add(newSymbol, fmt.Sprintf("signature changed from %s to %s", oldShape.signature, newShape.signature), true)
+	}
+
+	// a method added to an interface breaks its implementations, a field added to a struct does not break its users
+	memberLabel, addedIsBreaking := "field", false
+	if newKind == doc.INTERFACE_TYPE {
+		memberLabel, addedIsBreaking = "method", true
+	}
+
+	for _, name := range oldShape.memberNames {
+		newType, ok := newShape.members[name]
+		switch {
+		case !ok:
+			report.add(newSymbol, fmt.Sprintf("%s %s removed", memberLabel, name), true)
+		case newType != oldShape.members[name]:
+			report.add(newSymbol, fmt.Sprintf("%s %s changed from %s to %s", memberLabel, name, oldShape.members[name], newType), true)
+		}
+	}
+	for _, name := range newShape.memberNames {
+		if _, ok := oldShape.members[name]; !ok {
+			report.add(newSymbol, fmt.Sprintf("%s %s added", memberLabel, name), addedIsBreaking)
+		}
+	}
+}
+
+// shape is the part of a symbol that matters for its users: the types of a function, or the members of a type
+type shape struct {
+	signature   string
+	members     map[string]string
+	memberNames []string
+}
+
+func (s *shape) addMember(name, memberType string) {
+	if _, ok := s.members[name]; !ok {
+		s.memberNames = append(s.memberNames, name)
+	}
+	s.members[name] = memberType
+}
+
+func shapeOf(item doc.DocItem, useSource bool) shape {
+	s := shape{members: map[string]string{}}
+
+	if useSource {
+		if decl := parseDeclaration(item.GetBaseDoc().Signature); decl != nil {
+			sourceShape(&s, decl)
+			return s
+		}
+	}
+
+	switch value := item.(type) {
+	case *doc.FuncDoc:
+		s.signature = tagFuncType(*value)
+	case *doc.StructDoc:
+		for _, field := range value.Fields {
+			if token.IsExported(field.Name) {
+				s.addMember(field.Name, field.Type)
+			}
+		}
+	case *doc.InterfaceDoc:
+		for _, method := range value.Methods {
+			s.addMember(method.Name, tagFuncType(method))
+		}
+	}
+	return s
+}
+
+func sourceShape(s *shape, decl ast.Decl) {
+	switch value := decl.(type) {
+	case *ast.FuncDecl:
+		s.signature = funcType(value.Recv, value.Type)
+	case *ast.GenDecl:
+		if len(value.Specs) == 0 {
+			return
+		}
+		if valueSpec, ok := value.Specs[0].(*ast.ValueSpec); ok {
+			if valueSpec.Type != nil {
+				s.signature = exprString(valueSpec.Type)
+			}
+			return
+		}
+		typeSpec, ok := value.Specs[0].(*ast.TypeSpec)
+		if !ok {
+			return
+		}
+
+		s.signature = typeParams(typeSpec.TypeParams)
+		switch typeExpr := typeSpec.Type.(type) {
+		case *ast.StructType:
+			for _, field := range typeExpr.Fields.List {
+				for _, name := range fieldNames(field) {
+					if token.IsExported(name) {
+						s.addMember(name, exprString(field.Type))
+					}
+				}
+			}
+		case *ast.InterfaceType:
+			for _, method := range typeExpr.Methods.List {
+				if methodType, ok := method.Type.(*ast.FuncType); ok {
+					for _, name := range method.Names {
+						s.addMember(name.Name, funcType(nil, methodType))
+					}
+					continue
+				}
+				// embedded interface or type constraint
+				s.addMember(exprString(method.Type), "embedded")
+			}
+		default:
+			s.signature += exprString(typeSpec.Type)
+		}
+	}
+}
+
+// tagFuncType rebuilds the types of a function from its documentation tags
+func tagFuncType(fd doc.FuncDoc) string {
+	types := []string{}
+	for _, param := range fd.Params {
+		types = append(types, param.Type)
+	}
+
+	signature := "(" + strings.Join(types, ", ") + ")"
+	if fd.Return != nil && fd.Return.Type != "" {
+		signature += " " + strings.Trim(fd.Return.Type, "()")
+	}
+	return signature
+}
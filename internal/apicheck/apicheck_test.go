@@ -0,0 +1,152 @@
+package apicheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func function(name, receiver, signature string) *doc.FuncDoc {
+	return &doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: name, Type: doc.FUNCTION_TYPE, Signature: signature}, Struct: receiver}
+}
+
+func TestCheck(t *testing.T) {
+	baseline := doctest.ProjectFile("system", "system/runner.go",
+		function("Run", "Runner", "func (*Runner) Run(dir string, args ...string) error"),
+		function("Execute", "", "func Execute(name string) error"),
+		function("helper", "", "func helper()"),
+		&doc.StructDoc{
+			BaseDoc: doc.BaseDoc{Name: "Runner", Type: doc.STRUCT_TYPE, Signature: "type Runner struct {\n\tDir\tstring\n\tEnv\t[]string\n\tTimeout\tint\n\tcache\tmap[string]string\n}"},
+		},
+		&doc.InterfaceDoc{
+			BaseDoc: doc.BaseDoc{Name: "Executor", Type: doc.INTERFACE_TYPE, Signature: "type Executor interface {\n\tExecute(name string) error\n}"},
+		},
+	)
+	current := doctest.ProjectFile("system", "system/runner.go",
+		// renamed params are not a change
+		function("Run", "Runner", "func (r *Runner) Run(path string, values ...string) error"),
+		function("Execute", "", "func Execute(name string, timeout int) error"),
+		function("Stop", "Runner", "func (*Runner) Stop()"),
+		&doc.StructDoc{
+			BaseDoc: doc.BaseDoc{Name: "Runner", Type: doc.STRUCT_TYPE, Signature: "type Runner struct {\n\tDir, Shell\tstring\n\tEnv\tmap[string]string\n}"},
+		},
+		&doc.InterfaceDoc{
+			BaseDoc: doc.BaseDoc{Name: "Executor", Type: doc.INTERFACE_TYPE, Signature: "type Executor interface {\n\tExecute(command string) error\n\tClose() error\n}"},
+		},
+	)
+
+	report := Check(baseline, current)
+
+	assert.Equal(t, []Change{
+		{ID: "system.Execute", Kind: doc.FUNCTION_TYPE, Description: "signature changed from (string) error to (string, int) error", Breaking: true},
+		{ID: "system.Executor", Kind: doc.INTERFACE_TYPE, Description: "method Close added", Breaking: true},
+		{ID: "system.Runner", Kind: doc.STRUCT_TYPE, Description: "field Env changed from []string to map[string]string", Breaking: true},
+		{ID: "system.Runner", Kind: doc.STRUCT_TYPE, Description: "field Timeout removed", Breaking: true},
+	}, report.Breaking)
+	assert.Equal(t, []Change{
+		{ID: "system.Runner", Kind: doc.STRUCT_TYPE, Description: "field Shell added"},
+		{ID: "system.Runner.Stop", Kind: doc.FUNCTION_TYPE, Description: "function added"},
+	}, report.Compatible)
+	assert.True(t, report.HasBreakingChanges())
+
+	assert.False(t, Check(current, current).HasBreakingChanges())
+}
+
+func TestCheck_RemovedAndKindChanged(t *testing.T) {
+	baseline := doctest.ProjectFile("system", "system/runner.go",
+		&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "Runner", Type: doc.STRUCT_TYPE}},
+		function("Execute", "", "func Execute()"),
+	)
+	current := doctest.ProjectFile("system", "system/runner.go", &doc.InterfaceDoc{BaseDoc: doc.BaseDoc{Name: "Runner", Type: doc.INTERFACE_TYPE}})
+
+	assert.Equal(t, []Change{
+		{ID: "system.Execute", Kind: doc.FUNCTION_TYPE, Description: "function removed", Breaking: true},
+		{ID: "system.Runner", Kind: doc.INTERFACE_TYPE, Description: "kind changed from struct to interface", Breaking: true},
+	}, Check(baseline, current).Breaking)
+}
+
+func TestCheck_TypeParams(t *testing.T) {
+	baseline := doctest.ProjectFile("system", "system/runner.go",
+		function("Map", "", "func Map[T any](values []T) []T"),
+		&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "Set", Type: doc.STRUCT_TYPE, Signature: "type Set[K comparable] struct {\n\tItems\t[]K\n}"}},
+	)
+	current := doctest.ProjectFile("system", "system/runner.go",
+		function("Map", "", "func Map[T comparable](values []T) []T"),
+		&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "Set", Type: doc.STRUCT_TYPE, Signature: "type Set[K comparable, V any] struct {\n\tItems\t[]K\n}"}},
+	)
+
+	assert.Equal(t, []Change{
+		{ID: "system.Map", Kind: doc.FUNCTION_TYPE, Description: "signature changed from [any]([]T) []T to [comparable]([]T) []T", Breaking: true},
+		{ID: "system.Set", Kind: doc.STRUCT_TYPE, Description: "signature changed from [comparable] to [comparable, any]", Breaking: true},
+	}, Check(baseline, current).Breaking)
+}
+
+func TestCheck_WithoutSignatures(t *testing.T) {
+	// documentations generated before the signatures were recorded are compared with their tags
+	baseline := doctest.ProjectFile("system", "system/runner.go", &doc.FuncDoc{
+		BaseDoc: doc.BaseDoc{Name: "Execute", Type: doc.FUNCTION_TYPE},
+		Params:  []doc.Param{{Name: "name", Type: "string"}},
+		Return:  &doc.Return{Type: "error"},
+	})
+	current := doctest.ProjectFile("system", "system/runner.go", &doc.FuncDoc{
+		BaseDoc: doc.BaseDoc{Name: "Execute", Type: doc.FUNCTION_TYPE, Signature: "func Execute(command string) ([]byte, error)"},
+		Params:  []doc.Param{{Name: "command", Type: "string"}},
+		Return:  &doc.Return{Type: "([]byte, error)"},
+	})
+
+	assert.Equal(t, []Change{
+		{ID: "system.Execute", Kind: doc.FUNCTION_TYPE, Description: "signature changed from (string) error to (string) []byte, error", Breaking: true},
+	}, Check(baseline, current).Breaking)
+}
+
+func TestCheck_NotImportable(t *testing.T) {
+	// a documentation lists the internal and main packages, which other modules cannot use
+	baseline := doc.ProjectDoc{
+		PackageDocs: map[string][]doc.FileDoc{
+			"system": {{FileName: "runner.go", Path: "internal/system/runner.go", Docs: []doc.DocItem{function("Execute", "", "func Execute()")}}},
+			"main":   {{FileName: "main.go", Path: "main.go", Docs: []doc.DocItem{function("Run", "", "func Run()")}}},
+		},
+	}
+
+	report := Check(baseline, doc.ProjectDoc{PackageDocs: map[string][]doc.FileDoc{}})
+	assert.Empty(t, report.Breaking)
+	assert.Empty(t, report.Compatible)
+}
+
+func TestCheckVersion(t *testing.T) {
+	breaking := Report{Breaking: []Change{{ID: "system.Execute", Breaking: true}}}
+
+	assert.NoError(t, breaking.CheckVersion("1.4.0", "2.0.0"))
+	assert.ErrorContains(t, breaking.CheckVersion("1.4.0", "1.5.0"), "require a major version bump from 1.4.0 (at least 2.0.0)")
+	assert.NoError(t, breaking.CheckVersion("0.3.1", "0.4.0"))
+	assert.ErrorContains(t, breaking.CheckVersion("0.3.1", "0.3.2"), "require at least version 0.4.0")
+	assert.NoError(t, Report{}.CheckVersion("1.4.0", "1.4.1"))
+	assert.ErrorContains(t, breaking.CheckVersion("latest", "2.0.0"), "invalid baseline version")
+}
+
+func TestWriteText(t *testing.T) {
+	color.NoColor = true
+	report := Report{
+		Breaking:   []Change{{ID: "system.Execute", Kind: doc.FUNCTION_TYPE, Description: "function removed", Breaking: true}},
+		Compatible: []Change{{ID: "system.Runner", Kind: doc.STRUCT_TYPE, Description: "field Shell added"}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteText(&buf, report))
+	assert.Equal(t, "Breaking changes (1)\n  ! system.Execute (function): function removed\n\nCompatible changes (1)\n  + system.Runner (struct): field Shell added\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, WriteText(&buf, Report{}))
+	assert.Equal(t, "No API change\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, WriteJSON(&buf, report))
+	var decoded Report
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+}
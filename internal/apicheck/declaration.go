@@ -0,0 +1,91 @@
+package apicheck
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+// parseDeclaration parses a signature recorded by the parser, such as "func (*Runner) Run(dir string) error"
+func parseDeclaration(signature string) ast.Decl {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+signature, parser.SkipObjectResolution)
+	if err != nil || len(file.Decls) == 0 {
+		return nil
+	}
+	return file.Decls[0]
+}
+
+// funcType renders the receiver, type params, params and results of a function with their types only, so renaming a param is not a change
+func funcType(receiver *ast.FieldList, funcType *ast.FuncType) string {
+	signature := ""
+	if receiver != nil {
+		signature = "(" + strings.Join(fieldTypes(receiver), ", ") + ") "
+	}
+
+	signature += typeParams(funcType.TypeParams) + "(" + strings.Join(fieldTypes(funcType.Params), ", ") + ")"
+
+	results := fieldTypes(funcType.Results)
+	switch len(results) {
+	case 0:
+	case 1:
+		signature += " " + results[0]
+	default:
+		signature += " (" + strings.Join(results, ", ") + ")"
+	}
+	return signature
+}
+
+// typeParams renders the constraints of the type params, such as "[any, comparable]", or nothing when there is none
+func typeParams(params *ast.FieldList) string {
+	if params == nil || len(params.List) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(fieldTypes(params), ", ") + "]"
+}
+
+// fieldTypes lists one type per param, so "a, b string" and "a string, b string" are the same
+func fieldTypes(fields *ast.FieldList) []string {
+	types := []string{}
+	if fields == nil {
+		return types
+	}
+
+	for _, field := range fields.List {
+		fieldType := exprString(field.Type)
+		for range max(len(field.Names), 1) {
+			types = append(types, fieldType)
+		}
+	}
+	return types
+}
+
+// fieldNames returns the names of a struct field, or its type name when it is embedded
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		names := []string{}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		return names
+	}
+
+	embedded := field.Type
+	if star, ok := embedded.(*ast.StarExpr); ok {
+		embedded = star.X
+	}
+	if selector, ok := embedded.(*ast.SelectorExpr); ok {
+		return []string{selector.Sel.Name}
+	}
+	return []string{exprString(embedded)}
+}
+
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), expr); err != nil {
+		return ""
+	}
+	return buf.String()
+}
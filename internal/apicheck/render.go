@@ -0,0 +1,60 @@
+package apicheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+/*
+@description Write a report as colored text for the terminal
+@param writer io.Writer - The output
+@param report Report - The report to write
+@return error - An error if the writing fails
+@author Dorian TERBAH
+*/
+func WriteText(writer io.Writer, report Report) error {
+	var builder strings.Builder
+
+	if !report.HasBreakingChanges() && len(report.Compatible) == 0 {
+		builder.WriteString("No API change\n")
+	}
+
+	if len(report.Breaking) > 0 {
+		fmt.Fprintf(&builder, "Breaking changes (%d)\n", len(report.Breaking))
+		writeChanges(&builder, report.Breaking, color.New(color.FgRed).Sprint("!"))
+	}
+
+	if len(report.Compatible) > 0 {
+		if len(report.Breaking) > 0 {
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(&builder, "Compatible changes (%d)\n", len(report.Compatible))
+		writeChanges(&builder, report.Compatible, color.New(color.FgGreen).Sprint("+"))
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+/*
+@description Write a report as indented JSON
+@param writer io.Writer - The output
+@param report Report - The report to write
+@return error - An error if the writing fails
+@author Dorian TERBAH
+*/
+func WriteJSON(writer io.Writer, report Report) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func writeChanges(builder *strings.Builder, changes []Change, symbol string) {
+	for _, change := range changes {
+		fmt.Fprintf(builder, "  %s %s (%s): %s\n", symbol, change.ID, change.Kind, change.Description)
+	}
+}
@@ -0,0 +1,200 @@
+package apicheck
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/parser"
+)
+
+// Kinds of the exported declarations that have no documentation type
+const (
+	TYPE_KIND     = "type"
+	CONSTANT_KIND = "constant"
+	VARIABLE_KIND = "variable"
+)
+
+// declarationDoc is an exported declaration that is neither a function, a struct nor an interface
+type declarationDoc struct {
+	doc.BaseDoc
+}
+
+func (declaration *declarationDoc) GetBaseDoc() *doc.BaseDoc {
+	return &declaration.BaseDoc
+}
+
+/*
+@description Read the exported API of the Go packages of a file system from their declarations, documented or not. The packages that other modules cannot import are skipped: the packages under an internal folder and the main packages, as well as the test files and the testdata, vendor and hidden folders.
+@param ctx context.Context - The context of the parsing, checked before each file
+@param fsys fs.FS - The file system holding the code
+@param root string - The slash-separated folder of the module in the file system
+@return (*doc.ProjectDoc, error) - The exported declarations with their signatures read from the source code, and an error if a file is not valid Go
+@example ParseAPI(ctx, os.DirFS("."), ".")
+@author Dorian TERBAH
+*/
+func ParseAPI(ctx context.Context, fsys fs.FS, root string) (*doc.ProjectDoc, error) {
+	projectDoc := &doc.ProjectDoc{PackageDocs: map[string][]doc.FileDoc{}}
+
+	err := fs.WalkDir(fsys, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		relative := name
+		if root != "." {
+			relative = strings.TrimPrefix(name, root+"/")
+		}
+		if entry.IsDir() {
+			if name != root && !isImportableDir(entry.Name()) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || isInternalPath(relative) {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("error when reading the file %s: %w", name, err)
+		}
+
+		pckName, fileDoc, err := parseFileAPI(name, content)
+		if err != nil {
+			return err
+		}
+		if pckName == "main" || len(fileDoc.Docs) == 0 {
+			return nil
+		}
+
+		fileDoc.Path = filepath.FromSlash(relative)
+		projectDoc.PackageDocs[pckName] = append(projectDoc.PackageDocs[pckName], fileDoc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return projectDoc, nil
+}
+
+// parseFileAPI lists the exported declarations of a file, the methods being kept when their receiver is exported
+func parseFileAPI(name string, content []byte) (string, doc.FileDoc, error) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, name, content, goparser.SkipObjectResolution)
+	if err != nil {
+		return "", doc.FileDoc{}, fmt.Errorf("error when parsing the file %s: %w", name, err)
+	}
+
+	fileDoc := doc.FileDoc{FileName: path.Base(name), Docs: []doc.DocItem{}}
+	for _, decl := range file.Decls {
+		switch value := decl.(type) {
+		case *ast.FuncDecl:
+			receiver := receiverName(value.Recv)
+			if !value.Name.IsExported() || (value.Recv != nil && !token.IsExported(receiver)) {
+				continue
+			}
+			fileDoc.Docs = append(fileDoc.Docs, &doc.FuncDoc{
+				BaseDoc: doc.BaseDoc{Name: value.Name.Name, Type: doc.FUNCTION_TYPE, Signature: parser.FuncSignature(fset, value)},
+				Struct:  receiver,
+			})
+		case *ast.GenDecl:
+			fileDoc.Docs = append(fileDoc.Docs, genDeclAPI(fset, value)...)
+		}
+	}
+
+	return file.Name.Name, fileDoc, nil
+}
+
+func genDeclAPI(fset *token.FileSet, genDecl *ast.GenDecl) []doc.DocItem {
+	items := []doc.DocItem{}
+	for _, spec := range genDecl.Specs {
+		switch value := spec.(type) {
+		case *ast.TypeSpec:
+			if !value.Name.IsExported() {
+				continue
+			}
+
+			baseDoc := doc.BaseDoc{Name: value.Name.Name, Signature: parser.TypeSignature(fset, value)}
+			switch value.Type.(type) {
+			case *ast.StructType:
+				baseDoc.Type = doc.STRUCT_TYPE
+				items = append(items, &doc.StructDoc{BaseDoc: baseDoc})
+			case *ast.InterfaceType:
+				baseDoc.Type = doc.INTERFACE_TYPE
+				items = append(items, &doc.InterfaceDoc{BaseDoc: baseDoc})
+			default:
+				baseDoc.Type = TYPE_KIND
+				items = append(items, &declarationDoc{BaseDoc: baseDoc})
+			}
+		case *ast.ValueSpec:
+			kind := VARIABLE_KIND
+			if genDecl.Tok == token.CONST {
+				kind = CONSTANT_KIND
+			}
+			for _, name := range value.Names {
+				if !name.IsExported() {
+					continue
+				}
+				items = append(items, &declarationDoc{BaseDoc: doc.BaseDoc{Name: name.Name, Type: kind, Signature: valueSignature(genDecl.Tok, name.Name, value.Type)}})
+			}
+		}
+	}
+	return items
+}
+
+// valueSignature renders a constant or a variable with its declared type, without its value
+func valueSignature(tok token.Token, name string, valueType ast.Expr) string {
+	if valueType == nil {
+		return tok.String() + " " + name
+	}
+	return tok.String() + " " + name + " " + exprString(valueType)
+}
+
+// receiverName returns the type of a method receiver, without its pointer and its type parameters
+func receiverName(receiver *ast.FieldList) string {
+	if receiver == nil || len(receiver.List) == 0 {
+		return ""
+	}
+
+	expr := receiver.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch value := expr.(type) {
+	case *ast.IndexExpr:
+		expr = value.X
+	case *ast.IndexListExpr:
+		expr = value.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// isImportableDir checks if the go tool looks for packages in a folder
+func isImportableDir(name string) bool {
+	return name != "testdata" && name != "vendor" && !strings.HasPrefix(name, ".") && !strings.HasPrefix(name, "_")
+}
+
+// isInternalPath checks if a file belongs to an internal package, which only its parent module can import
+func isInternalPath(filePath string) bool {
+	for _, part := range strings.Split(path.Dir(filepath.ToSlash(filePath)), "/") {
+		if part == "internal" {
+			return true
+		}
+	}
+	return false
+}
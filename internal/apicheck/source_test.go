@@ -0,0 +1,106 @@
+package apicheck
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAPI(t *testing.T) {
+	fsys := fstest.MapFS{
+		"module/runner/runner.go": {Data: []byte(`package runner
+
+// Runner is documented without the zendoc tags
+type Runner struct {
+	Dir string
+	env []string
+}
+
+type Mode int
+
+const Quiet Mode = 1
+
+var Default, fallback = New(), New()
+
+func New() *Runner { return &Runner{} }
+
+func (runner *Runner) Run(dir string, args ...string) error { return nil }
+
+func (runner *Runner) reset() {}
+
+type list[T any] []T
+
+func (l list[T]) Len() int { return len(l) }
+
+type Set[T comparable] map[T]bool
+
+func (s Set[T]) Has(value T) bool { return s[value] }
+`)},
+		"module/runner/runner_test.go":     {Data: []byte("package runner\n\nfunc TestHelper() {}\n")},
+		"module/internal/system/system.go": {Data: []byte("package system\n\nfunc Execute() {}\n")},
+		"module/cmd/zendoc/main.go":        {Data: []byte("package main\n\nfunc Execute() {}\n")},
+		"module/runner/testdata/broken.go": {Data: []byte("package broken\n\nfunc {\n")},
+		"module/.git/hooks.go":             {Data: []byte("not go")},
+		"module/README.md":                 {Data: []byte("# module")},
+	}
+
+	projectDoc, err := ParseAPI(context.Background(), fsys, "module")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"runner"}, keys(projectDoc.PackageDocs))
+	assert.Equal(t, "runner/runner.go", projectDoc.PackageDocs["runner"][0].Path)
+
+	signatures := map[string]string{}
+	for _, symbol := range doc.Symbols(*projectDoc) {
+		signatures[symbol.ID+" "+symbol.Item.GetBaseDoc().Type] = symbol.Item.GetBaseDoc().Signature
+	}
+	assert.Equal(t, map[string]string{
+		"runner.Runner struct":       "type Runner struct {\n\tDir\tstring\n\tenv\t[]string\n}",
+		"runner.Mode type":           "type Mode int",
+		"runner.Quiet constant":      "const Quiet Mode",
+		"runner.Default variable":    "var Default",
+		"runner.New function":        "func New() *Runner",
+		"runner.Runner.Run function": "func (*Runner) Run(dir string, args ...string) error",
+		"runner.Set type":            "type Set[T comparable] map[T]bool",
+		"runner.Set.Has function":    "func (Set[T]) Has(value T) bool",
+	}, signatures)
+
+	_, err = ParseAPI(context.Background(), fstest.MapFS{"broken.go": {Data: []byte("package broken\n\nfunc {\n")}}, ".")
+	assert.ErrorContains(t, err, "error when parsing the file broken.go")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ParseAPI(ctx, fsys, "module")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParseAPI_Check(t *testing.T) {
+	baseline, err := ParseAPI(context.Background(), fstest.MapFS{
+		"runner.go": {Data: []byte("package runner\n\nconst Quiet = 1\n\nvar Timeout int\n\ntype Mode int\n\nfunc undocumented(dir string) {}\n")},
+	}, ".")
+	assert.NoError(t, err)
+	current, err := ParseAPI(context.Background(), fstest.MapFS{
+		"runner.go": {Data: []byte("package runner\n\nvar Timeout int64\n\ntype Mode string\n\nfunc Undocumented(dir string) {}\n")},
+	}, ".")
+	assert.NoError(t, err)
+
+	report := Check(*baseline, *current)
+	assert.Equal(t, []Change{
+		{ID: "runner.Mode", Kind: TYPE_KIND, Description: "signature changed from int to string", Breaking: true},
+		{ID: "runner.Quiet", Kind: CONSTANT_KIND, Description: "constant removed", Breaking: true},
+		{ID: "runner.Timeout", Kind: VARIABLE_KIND, Description: "signature changed from int to int64", Breaking: true},
+	}, report.Breaking)
+	assert.Equal(t, []Change{
+		{ID: "runner.Undocumented", Kind: doc.FUNCTION_TYPE, Description: "function added"},
+	}, report.Compatible)
+}
+
+func keys(packageDocs map[string][]doc.FileDoc) []string {
+	names := []string{}
+	for name := range packageDocs {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,192 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/diff"
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/semver"
+)
+
+const CHANGELOG_FILE = "CHANGELOG.md"
+
+/*
+@description Struct to represent the changelog entry of a documentation version
+@author Dorian TERBAH
+@field Version string - The documented version
+@field Previous string - The version it is compared with
+@field Date string - The date of the generation, as YYYY-MM-DD
+@field Added []diff.Entry - The symbols added since the previous version
+@field Deprecated []diff.Entry - The symbols deprecated since the previous version, with their deprecation message
+@field Removed []diff.Entry - The symbols removed since the previous version
+*/
+type Changelog struct {
+	Version    string       `json:"version"`
+	Previous   string       `json:"previous"`
+	Date       string       `json:"date"`
+	Added      []diff.Entry `json:"added"`
+	Deprecated []diff.Entry `json:"deprecated"`
+	Removed    []diff.Entry `json:"removed"`
+}
+
+/*
+@description Build the changelog entry of a version from the documentation of the previous version
+@param previous string - The previous version
+@param oldDoc doc.ProjectDoc - The documentation of the previous version
+@param version string - The new version
+@param newDoc doc.ProjectDoc - The documentation of the new version
+@param date string - The date of the generation
+@return Changelog - The added, deprecated and removed symbols
+@example Build("1.0.0", oldDoc, "1.1.0", newDoc, "2025-04-12")
+@author Dorian TERBAH
+*/
+func Build(previous string, oldDoc doc.ProjectDoc, version string, newDoc doc.ProjectDoc, date string) Changelog {
+	report := diff.Compare(previous, oldDoc, version, newDoc)
+
+	return Changelog{
+		Version:    version,
+		Previous:   previous,
+		Date:       date,
+		Added:      report.Added,
+		Deprecated: report.Deprecated,
+		Removed:    report.Removed,
+	}
+}
+
+/*
+@description Find the version preceding a version among the recorded ones: the highest lower semantic version, or the version recorded just before it when the versions are not semantic
+@param versions []string - The recorded versions
+@param version string - The new version
+@return string - The previous version, or an empty string if there is none
+@example PreviousVersion([]string{"1.0.0", "1.2.0", "1.1.0"}, "1.1.5") => "1.1.0"
+@author Dorian TERBAH
+*/
+func PreviousVersion(versions []string, version string) string {
+	current, err := semver.Parse(version)
+	if err == nil {
+		previous, found := "", semver.Version{}
+		for _, candidate := range versions {
+			parsed, err := semver.Parse(candidate)
+			if err != nil || parsed.Compare(current) >= 0 {
+				continue
+			}
+			if previous == "" || parsed.Compare(found) > 0 {
+				previous, found = candidate, parsed
+			}
+		}
+		return previous
+	}
+
+	previous := ""
+	for _, candidate := range versions {
+		if candidate == version {
+			break
+		}
+		previous = candidate
+	}
+	return previous
+}
+
+/*
+@description Render the changelog entry as a Markdown section, in the style of a CHANGELOG file
+@return string - The Markdown section, starting with a "## [version] - date" heading
+@author Dorian TERBAH
+*/
+func (changelog Changelog) Markdown() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%s\n\n", changelog.heading())
+	fmt.Fprintf(&builder, "API changes since %s.\n", changelog.Previous)
+
+	sections := []struct {
+		title   string
+		entries []diff.Entry
+	}{
+		{title: "Added", entries: changelog.Added},
+		{title: "Deprecated", entries: changelog.Deprecated},
+		{title: "Removed", entries: changelog.Removed},
+	}
+
+	empty := true
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		empty = false
+
+		fmt.Fprintf(&builder, "\n### %s\n\n", section.title)
+		for _, entry := range section.entries {
+			fmt.Fprintf(&builder, "- `%s` (%s)", entry.ID, entry.Kind)
+			if entry.Message != "" {
+				fmt.Fprintf(&builder, ": %s", entry.Message)
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	if empty {
+		builder.WriteString("\nNo API change.\n")
+	}
+
+	return builder.String()
+}
+
+/*
+@description Insert the entry in the content of a CHANGELOG file, newest first. The existing entry of the same version is replaced, so generating a version twice keeps a single entry.
+@param content string - The current content of the file, empty if it does not exist yet
+@return string - The updated content
+@author Dorian TERBAH
+*/
+func (changelog Changelog) Insert(content string) string {
+	entry := changelog.Markdown()
+	versionHeading := fmt.Sprintf("## [%s]", changelog.Version)
+
+	if content == "" {
+		return "# Changelog\n\n" + entry
+	}
+
+	sections := splitSections(content)
+	for i, section := range sections {
+		if strings.HasPrefix(section, versionHeading+" ") || strings.TrimSpace(section) == versionHeading {
+			sections[i] = entry
+			return joinSections(sections)
+		}
+	}
+
+	// the entry goes before the first version, after the title of the file
+	for i, section := range sections {
+		if strings.HasPrefix(section, "## ") {
+			sections = append(sections[:i], append([]string{entry}, sections[i:]...)...)
+			return joinSections(sections)
+		}
+	}
+
+	return joinSections(append(sections, entry))
+}
+
+func (changelog Changelog) heading() string {
+	return fmt.Sprintf("## [%s] - %s", changelog.Version, changelog.Date)
+}
+
+// splitSections splits a Markdown file before each "## " heading, the first section being the content before them
+func splitSections(content string) []string {
+	sections := []string{}
+	current := []string{}
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		if strings.HasPrefix(line, "## ") && len(current) > 0 {
+			sections = append(sections, strings.Join(current, "\n")+"\n")
+			current = []string{}
+		}
+		current = append(current, line)
+	}
+	return append(sections, strings.Join(current, "\n")+"\n")
+}
+
+func joinSections(sections []string) string {
+	trimmed := []string{}
+	for _, section := range sections {
+		trimmed = append(trimmed, strings.TrimRight(section, "\n"))
+	}
+	return strings.Join(trimmed, "\n\n") + "\n"
+}
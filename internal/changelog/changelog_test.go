@@ -0,0 +1,79 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/diff"
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleChangelog() Changelog {
+	oldDoc := doctest.Project(
+		&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "ParseDocForFile", Type: doc.FUNCTION_TYPE}, Struct: "DocParser"},
+		&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "getPackageName", Type: doc.FUNCTION_TYPE}},
+	)
+	newDoc := doctest.Project(
+		&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "ParseDocForFile", Type: doc.FUNCTION_TYPE, Deprecated: "Use ParseFS"}, Struct: "DocParser"},
+		&doc.InterfaceDoc{BaseDoc: doc.BaseDoc{Name: "Parser", Type: doc.INTERFACE_TYPE}},
+	)
+
+	return Build("1.0.0", oldDoc, "1.1.0", newDoc, "2025-04-12")
+}
+
+func TestBuild(t *testing.T) {
+	changelog := sampleChangelog()
+
+	assert.Equal(t, "1.1.0", changelog.Version)
+	assert.Equal(t, "1.0.0", changelog.Previous)
+	assert.Equal(t, []diff.Entry{{ID: "parser.Parser", Kind: doc.INTERFACE_TYPE}}, changelog.Added)
+	assert.Equal(t, []diff.Entry{{ID: "parser.DocParser.ParseDocForFile", Kind: doc.FUNCTION_TYPE, Message: "Use ParseFS"}}, changelog.Deprecated)
+	assert.Equal(t, []diff.Entry{{ID: "parser.getPackageName", Kind: doc.FUNCTION_TYPE}}, changelog.Removed)
+}
+
+func TestPreviousVersion(t *testing.T) {
+	assert.Equal(t, "1.1.0", PreviousVersion([]string{"1.0.0", "1.2.0", "1.1.0"}, "1.1.5"))
+	assert.Equal(t, "", PreviousVersion([]string{"1.0.0"}, "1.0.0"))
+	assert.Equal(t, "1.0.0", PreviousVersion([]string{"1.0.0", "latest"}, "1.1.0"))
+	assert.Equal(t, "beta", PreviousVersion([]string{"alpha", "beta", "rc"}, "rc"))
+	assert.Equal(t, "rc", PreviousVersion([]string{"alpha", "beta", "rc"}, "final"))
+}
+
+func TestMarkdown(t *testing.T) {
+	assert.Equal(t, `## [1.1.0] - 2025-04-12
+
+API changes since 1.0.0.
+
+### Added
+
+- `+"`parser.Parser`"+` (interface)
+
+### Deprecated
+
+- `+"`parser.DocParser.ParseDocForFile`"+` (function): Use ParseFS
+
+### Removed
+
+- `+"`parser.getPackageName`"+` (function)
+`, sampleChangelog().Markdown())
+
+	empty := Build("1.0.0", doctest.Project(), "1.0.1", doctest.Project(), "2025-04-12")
+	assert.Equal(t, "## [1.0.1] - 2025-04-12\n\nAPI changes since 1.0.0.\n\nNo API change.\n", empty.Markdown())
+}
+
+func TestInsert(t *testing.T) {
+	first := Build("0.9.0", doctest.Project(), "1.0.0", doctest.Project(), "2025-04-01")
+	second := Build("1.0.0", doctest.Project(), "1.0.1", doctest.Project(), "2025-04-12")
+
+	content := first.Insert("")
+	assert.Equal(t, "# Changelog\n\n"+first.Markdown(), content)
+
+	content = second.Insert(content)
+	assert.Equal(t, "# Changelog\n\n"+second.Markdown()+"\n"+first.Markdown(), content)
+
+	// generating a version again replaces its entry
+	second.Date = "2025-04-13"
+	content = second.Insert(content)
+	assert.Equal(t, "# Changelog\n\n"+second.Markdown()+"\n"+first.Markdown(), content)
+}
@@ -1,4 +1,22 @@
 package internal
 
+import "time"
+
 const WEB_EXPORT_TYPE = "web"
 const JSON_EXPORT_TYPE = "json"
+const JSONL_EXPORT_TYPE = "jsonl"
+const MARKDOWN_EXPORT_TYPE = "markdown"
+const HTML_EXPORT_TYPE = "html"
+const TEMPLATE_EXPORT_TYPE = "template"
+const SQLITE_EXPORT_TYPE = "sqlite"
+
+var EXPORT_TYPES = []string{JSON_EXPORT_TYPE, JSONL_EXPORT_TYPE, WEB_EXPORT_TYPE, MARKDOWN_EXPORT_TYPE, HTML_EXPORT_TYPE, TEMPLATE_EXPORT_TYPE, SQLITE_EXPORT_TYPE}
+
+// ZENDOC_VERSION is the version of the zendoc binary, set when building a release with -ldflags "-X github.com/dterbah/zendoc/internal.ZENDOC_VERSION=<version>"
+var ZENDOC_VERSION = "dev"
+
+// Default timeout of an external command, such as git or npm. It is changed with the --command-timeout flag.
+const DEFAULT_COMMAND_TIMEOUT = 10 * time.Minute
+
+// Time given to the server to finish the requests in progress when it stops
+const SHUTDOWN_TIMEOUT = 5 * time.Second
@@ -0,0 +1,185 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+)
+
+/*
+@description Struct to represent a symbol in a diff report
+@author Dorian TERBAH
+@field ID string - The identifier of the symbol (e.g. parser.DocParser.ParseDocForDir)
+@field Kind string - The kind of the symbol (function, struct, interface, interface-method)
+@field Changes []string - The description of each change, for changed symbols
+@field Message string - The deprecation message, for newly deprecated symbols
+*/
+type Entry struct {
+	ID      string   `json:"id"`
+	Kind    string   `json:"kind"`
+	Changes []string `json:"changes,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+/*
+@description Struct to represent the differences between two versions of a documentation
+@author Dorian TERBAH
+@field From string - The name of the old version
+@field To string - The name of the new version
+@field Added []Entry - The symbols only in the new version
+@field Removed []Entry - The symbols only in the old version
+@field Changed []Entry - The symbols whose kind, params, fields or return type changed
+@field Deprecated []Entry - The symbols deprecated in the new version but not in the old one
+*/
+type Report struct {
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+	Added      []Entry `json:"added"`
+	Removed    []Entry `json:"removed"`
+	Changed    []Entry `json:"changed"`
+	Deprecated []Entry `json:"deprecated"`
+}
+
+/*
+@description Check if the report contains no difference
+@return bool - true if both versions have the same symbols
+@author Dorian TERBAH
+*/
+func (report Report) IsEmpty() bool {
+	return len(report.Added) == 0 && len(report.Removed) == 0 && len(report.Changed) == 0 && len(report.Deprecated) == 0
+}
+
+/*
+@description Compare two versions of a documentation, symbol by symbol
+@param from string - The name of the old version
+@param oldDoc doc.ProjectDoc - The old documentation
+@param to string - The name of the new version
+@param newDoc doc.ProjectDoc - The new documentation
+@return Report - The differences, sorted by symbol identifier
+@example Compare("1.0.0", oldDoc, "1.1.0", newDoc)
+@author Dorian TERBAH
+*/
+func Compare(from string, oldDoc doc.ProjectDoc, to string, newDoc doc.ProjectDoc) Report {
+	report := Report{
+		From:       from,
+		To:         to,
+		Added:      []Entry{},
+		Removed:    []Entry{},
+		Changed:    []Entry{},
+		Deprecated: []Entry{},
+	}
+
+	oldSymbols := doc.Symbols(oldDoc)
+	newSymbols := doc.Symbols(newDoc)
+
+	oldByID := map[string]doc.Symbol{}
+	for _, symbol := range oldSymbols {
+		oldByID[symbol.ID] = symbol
+	}
+	newByID := map[string]doc.Symbol{}
+	for _, symbol := range newSymbols {
+		newByID[symbol.ID] = symbol
+	}
+
+	for _, symbol := range oldSymbols {
+		if _, ok := newByID[symbol.ID]; !ok {
+			report.Removed = append(report.Removed, newEntry(symbol))
+		}
+	}
+
+	for _, symbol := range newSymbols {
+		oldSymbol, ok := oldByID[symbol.ID]
+		if !ok {
+			report.Added = append(report.Added, newEntry(symbol))
+			continue
+		}
+
+		if changes := compareItems(oldSymbol.Item, symbol.Item); len(changes) > 0 {
+			entry := newEntry(symbol)
+			entry.Changes = changes
+			report.Changed = append(report.Changed, entry)
+		}
+
+		oldBase, newBase := oldSymbol.Item.GetBaseDoc(), symbol.Item.GetBaseDoc()
+		if oldBase.Deprecated == "" && newBase.Deprecated != "" {
+			entry := newEntry(symbol)
+			entry.Message = newBase.Deprecated
+			report.Deprecated = append(report.Deprecated, entry)
+		}
+	}
+
+	return report
+}
+
+func newEntry(symbol doc.Symbol) Entry {
+	return Entry{ID: symbol.ID, Kind: symbol.Item.GetBaseDoc().Type}
+}
+
+// compareItems lists the changes of the kind, params, fields and return type of a symbol
+func compareItems(oldItem, newItem doc.DocItem) []string {
+	oldKind, newKind := oldItem.GetBaseDoc().Type, newItem.GetBaseDoc().Type
+	if oldKind != newKind {
+		return []string{fmt.Sprintf("kind changed from %s to %s", oldKind, newKind)}
+	}
+
+	changes := []string{}
+	switch newValue := newItem.(type) {
+	case *doc.FuncDoc:
+		oldValue := oldItem.(*doc.FuncDoc)
+		changes = append(changes, compareParams("param", oldValue.Params, newValue.Params)...)
+		oldReturn, newReturn := returnType(oldValue.Return), returnType(newValue.Return)
+		if oldReturn != newReturn {
+			changes = append(changes, fmt.Sprintf("return type changed from %s to %s", display(oldReturn), display(newReturn)))
+		}
+	case *doc.StructDoc:
+		oldValue := oldItem.(*doc.StructDoc)
+		changes = append(changes, compareParams("field", oldValue.Fields, newValue.Fields)...)
+	}
+
+	return changes
+}
+
+// compareParams matches params or fields by name, and reports the removed, added and retyped ones
+func compareParams(label string, oldParams, newParams []doc.Param) []string {
+	changes := []string{}
+
+	newTypes := map[string]string{}
+	for _, param := range newParams {
+		newTypes[param.Name] = param.Type
+	}
+	oldTypes := map[string]string{}
+	for _, param := range oldParams {
+		oldTypes[param.Name] = param.Type
+
+		newType, ok := newTypes[param.Name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s %s removed", label, param.Name))
+		case newType != param.Type:
+			changes = append(changes, fmt.Sprintf("%s %s type changed from %s to %s", label, param.Name, display(param.Type), display(newType)))
+		}
+	}
+
+	for _, param := range newParams {
+		if _, ok := oldTypes[param.Name]; !ok {
+			changes = append(changes, fmt.Sprintf("%s %s added", label, strings.TrimSpace(param.Name+" "+param.Type)))
+		}
+	}
+
+	return changes
+}
+
+func returnType(value *doc.Return) string {
+	if value == nil {
+		return ""
+	}
+	return value.Type
+}
+
+func display(typeName string) string {
+	if typeName == "" {
+		return "(none)"
+	}
+	return typeName
+}
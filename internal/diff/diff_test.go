@@ -0,0 +1,105 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleReport() Report {
+	oldDoc := doctest.Project(
+		&doc.FuncDoc{
+			BaseDoc: doc.BaseDoc{Name: "ParseDocForDir", Type: doc.FUNCTION_TYPE},
+			Params:  []doc.Param{{Name: "dirPath", Type: "string"}, {Name: "currentPath", Type: "string"}},
+			Return:  &doc.Return{Type: "*doc.ProjectDoc"},
+			Struct:  "DocParser",
+		},
+		&doc.StructDoc{
+			BaseDoc: doc.BaseDoc{Name: "DocParser", Type: doc.STRUCT_TYPE},
+			Fields:  []doc.StructField{{Name: "FileValidators", Type: "[]DocParserFileValidator"}},
+		},
+		&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "getPackageName", Type: doc.FUNCTION_TYPE}},
+		&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "ParseDocForFile", Type: doc.FUNCTION_TYPE}, Struct: "DocParser"},
+	)
+	newDoc := doctest.Project(
+		&doc.FuncDoc{
+			BaseDoc: doc.BaseDoc{Name: "ParseDocForDir", Type: doc.FUNCTION_TYPE},
+			Params:  []doc.Param{{Name: "dirPath", Type: "fs.FS"}, {Name: "ctx", Type: "context.Context"}},
+			Return:  &doc.Return{Type: "(*doc.ProjectDoc, error)"},
+			Struct:  "DocParser",
+		},
+		&doc.StructDoc{
+			BaseDoc: doc.BaseDoc{Name: "DocParser", Type: doc.STRUCT_TYPE},
+			Fields:  []doc.StructField{{Name: "FileValidators", Type: "[]DocParserFileValidator"}, {Name: "Quiet", Type: "bool"}},
+		},
+		&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "ParseDocForFile", Type: doc.FUNCTION_TYPE, Deprecated: "Use ParseFS"}, Struct: "DocParser"},
+		&doc.InterfaceDoc{BaseDoc: doc.BaseDoc{Name: "Parser", Type: doc.INTERFACE_TYPE}},
+	)
+
+	return Compare("1.0.0", oldDoc, "2.0.0", newDoc)
+}
+
+func TestCompare(t *testing.T) {
+	report := sampleReport()
+
+	assert.Equal(t, []Entry{{ID: "parser.Parser", Kind: doc.INTERFACE_TYPE}}, report.Added)
+	assert.Equal(t, []Entry{{ID: "parser.getPackageName", Kind: doc.FUNCTION_TYPE}}, report.Removed)
+	assert.Equal(t, []Entry{
+		{ID: "parser.DocParser", Kind: doc.STRUCT_TYPE, Changes: []string{"field Quiet bool added"}},
+		{ID: "parser.DocParser.ParseDocForDir", Kind: doc.FUNCTION_TYPE, Changes: []string{
+			"param dirPath type changed from string to fs.FS",
+			"param currentPath removed",
+			"param ctx context.Context added",
+			"return type changed from *doc.ProjectDoc to (*doc.ProjectDoc, error)",
+		}},
+	}, report.Changed)
+	assert.Equal(t, []Entry{{ID: "parser.DocParser.ParseDocForFile", Kind: doc.FUNCTION_TYPE, Message: "Use ParseFS"}}, report.Deprecated)
+	assert.False(t, report.IsEmpty())
+
+	assert.True(t, Compare("1.0.0", doctest.Project(), "1.0.1", doctest.Project()).IsEmpty())
+}
+
+func TestCompare_KindChanged(t *testing.T) {
+	report := Compare("1", doctest.Project(&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "Runner", Type: doc.STRUCT_TYPE}}),
+		"2", doctest.Project(&doc.InterfaceDoc{BaseDoc: doc.BaseDoc{Name: "Runner", Type: doc.INTERFACE_TYPE}}))
+
+	assert.Equal(t, []string{"kind changed from struct to interface"}, report.Changed[0].Changes)
+}
+
+func TestWrite(t *testing.T) {
+	color.NoColor = true
+	report := sampleReport()
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, report, TEXT_FORMAT))
+	text := buf.String()
+	assert.Contains(t, text, "API changes from 1.0.0 to 2.0.0\n")
+	assert.Contains(t, text, "Added (1)\n  + parser.Parser (interface)\n")
+	assert.Contains(t, text, "Removed (1)\n  - parser.getPackageName (function)\n")
+	assert.Contains(t, text, "  ~ parser.DocParser.ParseDocForDir (function)\n      param dirPath type changed from string to fs.FS\n")
+	assert.Contains(t, text, "Newly deprecated (1)\n  ! parser.DocParser.ParseDocForFile (function)\n      Use ParseFS\n")
+
+	buf.Reset()
+	assert.NoError(t, Write(&buf, report, MARKDOWN_FORMAT))
+	markdown := buf.String()
+	assert.Contains(t, markdown, "# API changes from 1.0.0 to 2.0.0\n")
+	assert.Contains(t, markdown, "## Changed\n\n- `parser.DocParser` (struct)\n  - field Quiet bool added\n")
+	assert.Contains(t, markdown, "## Newly deprecated\n\n- `parser.DocParser.ParseDocForFile` (function): Use ParseFS\n")
+
+	buf.Reset()
+	assert.NoError(t, Write(&buf, report, JSON_FORMAT))
+	var decoded Report
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+
+	buf.Reset()
+	assert.NoError(t, Write(&buf, Compare("1", doctest.Project(), "2", doctest.Project()), TEXT_FORMAT))
+	assert.Contains(t, buf.String(), "No change")
+
+	assert.ErrorContains(t, Write(&buf, report, "yaml"), "unknown format")
+}
@@ -0,0 +1,141 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+const TEXT_FORMAT = "text"
+const MARKDOWN_FORMAT = "markdown"
+const JSON_FORMAT = "json"
+
+var FORMATS = []string{TEXT_FORMAT, MARKDOWN_FORMAT, JSON_FORMAT}
+
+// section is a titled list of entries of a report, in display order
+type section struct {
+	title   string
+	symbol  string
+	color   *color.Color
+	entries []Entry
+}
+
+func (report Report) sections() []section {
+	return []section{
+		{title: "Added", symbol: "+", color: color.New(color.FgGreen), entries: report.Added},
+		{title: "Removed", symbol: "-", color: color.New(color.FgRed), entries: report.Removed},
+		{title: "Changed", symbol: "~", color: color.New(color.FgYellow), entries: report.Changed},
+		{title: "Newly deprecated", symbol: "!", color: color.New(color.FgMagenta), entries: report.Deprecated},
+	}
+}
+
+/*
+@description Write a report in the given format
+@param writer io.Writer - The output
+@param report Report - The report to write
+@param format string - Either "text", "markdown" or "json"
+@return error - An error if the format is unknown or the writing fails
+@author Dorian TERBAH
+*/
+func Write(writer io.Writer, report Report, format string) error {
+	switch format {
+	case "", TEXT_FORMAT:
+		return WriteText(writer, report)
+	case MARKDOWN_FORMAT:
+		return WriteMarkdown(writer, report)
+	case JSON_FORMAT:
+		return WriteJSON(writer, report)
+	}
+
+	return fmt.Errorf("unknown format \"%s\", expected one of: %s", format, strings.Join(FORMATS, ", "))
+}
+
+/*
+@description Write a report as colored text for the terminal
+@param writer io.Writer - The output
+@param report Report - The report to write
+@return error - An error if the writing fails
+@author Dorian TERBAH
+*/
+func WriteText(writer io.Writer, report Report) error {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "API changes from %s to %s\n", report.From, report.To)
+
+	if report.IsEmpty() {
+		builder.WriteString("\nNo change\n")
+	}
+
+	for _, section := range report.sections() {
+		if len(section.entries) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&builder, "\n%s (%d)\n", section.title, len(section.entries))
+		for _, entry := range section.entries {
+			fmt.Fprintf(&builder, "  %s %s (%s)\n", section.color.Sprint(section.symbol), entry.ID, entry.Kind)
+			for _, change := range entry.Changes {
+				fmt.Fprintf(&builder, "      %s\n", change)
+			}
+			if entry.Message != "" {
+				fmt.Fprintf(&builder, "      %s\n", entry.Message)
+			}
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+/*
+@description Write a report as Markdown, for release notes and code reviews
+@param writer io.Writer - The output
+@param report Report - The report to write
+@return error - An error if the writing fails
+@author Dorian TERBAH
+*/
+func WriteMarkdown(writer io.Writer, report Report) error {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# API changes from %s to %s\n", report.From, report.To)
+
+	if report.IsEmpty() {
+		builder.WriteString("\nNo change.\n")
+	}
+
+	for _, section := range report.sections() {
+		if len(section.entries) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&builder, "\n## %s\n\n", section.title)
+		for _, entry := range section.entries {
+			fmt.Fprintf(&builder, "- `%s` (%s)", entry.ID, entry.Kind)
+			if entry.Message != "" {
+				fmt.Fprintf(&builder, ": %s", entry.Message)
+			}
+			builder.WriteString("\n")
+			for _, change := range entry.Changes {
+				fmt.Fprintf(&builder, "  - %s\n", change)
+			}
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+/*
+@description Write a report as indented JSON
+@param writer io.Writer - The output
+@param report Report - The report to write
+@return error - An error if the serialization fails
+@author Dorian TERBAH
+*/
+func WriteJSON(writer io.Writer, report Report) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,123 @@
+package doc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// fileDocJSON is the serialized form of a FileDoc, with the items kept raw until their type is known
+type fileDocJSON struct {
+	FileName string            `json:"filename"`
+	Path     string            `json:"path"`
+	Docs     []json.RawMessage `json:"docs"`
+}
+
+/*
+@description Create an empty documentation item from its type discriminator
+@param docType string - The value of the "type" field of the item
+@return (DocItem, error) - A pointer to an empty item of the matching kind and an error if the type is unknown
+@example NewDocItem("struct") => &StructDoc{}, nil
+@author Dorian TERBAH
+*/
+func NewDocItem(docType string) (DocItem, error) {
+	switch docType {
+	case FUNCTION_TYPE, INTERFACE_METHOD_TYPE:
+		return &FuncDoc{}, nil
+	case STRUCT_TYPE:
+		return &StructDoc{}, nil
+	case INTERFACE_TYPE:
+		return &InterfaceDoc{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown documentation type \"%s\"", docType)
+}
+
+/*
+@description Serialize a file documentation, checking that every item carries a known type discriminator
+@return ([]byte, error) - The JSON representation of the file and an error if an item cannot be serialized
+@author Dorian TERBAH
+*/
+func (fileDoc FileDoc) MarshalJSON() ([]byte, error) {
+	raw := fileDocJSON{
+		FileName: fileDoc.FileName,
+		Path:     fileDoc.Path,
+		Docs:     make([]json.RawMessage, 0, len(fileDoc.Docs)),
+	}
+
+	for _, item := range fileDoc.Docs {
+		if item == nil {
+			return nil, fmt.Errorf("nil documentation item in file %s", fileDoc.Path)
+		}
+		if _, err := NewDocItem(item.GetBaseDoc().Type); err != nil {
+			return nil, fmt.Errorf("error when serializing %s in file %s: %w", item.GetBaseDoc().Name, fileDoc.Path, err)
+		}
+
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		raw.Docs = append(raw.Docs, data)
+	}
+
+	return json.Marshal(raw)
+}
+
+/*
+@description Deserialize a file documentation, using the "type" field of each item to recover its concrete kind
+@param data []byte - The JSON representation of the file
+@return error - An error if the JSON is invalid or an item has an unknown type
+@author Dorian TERBAH
+*/
+func (fileDoc *FileDoc) UnmarshalJSON(data []byte) error {
+	var raw fileDocJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	docs := make([]DocItem, 0, len(raw.Docs))
+	for _, rawItem := range raw.Docs {
+		var discriminator struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(rawItem, &discriminator); err != nil {
+			return err
+		}
+
+		item, err := NewDocItem(discriminator.Type)
+		if err != nil {
+			return fmt.Errorf("error when reading file %s: %w", raw.Path, err)
+		}
+
+		if err := json.Unmarshal(rawItem, item); err != nil {
+			return err
+		}
+		docs = append(docs, item)
+	}
+
+	fileDoc.FileName = raw.FileName
+	fileDoc.Path = raw.Path
+	fileDoc.Docs = docs
+	return nil
+}
+
+/*
+@description Load a project documentation previously serialized in JSON (e.g. a doc.json file)
+@param reader io.Reader - The reader providing the JSON content
+@return (*ProjectDoc, error) - The loaded documentation and an error if the content is invalid
+@example LoadProjectDoc(file) => &ProjectDoc{...}, nil
+@author Dorian TERBAH
+*/
+func LoadProjectDoc(reader io.Reader) (*ProjectDoc, error) {
+	projectDoc := &ProjectDoc{}
+
+	if err := json.NewDecoder(reader).Decode(projectDoc); err != nil {
+		return nil, fmt.Errorf("error when loading the documentation: %w", err)
+	}
+
+	if projectDoc.PackageDocs == nil {
+		projectDoc.PackageDocs = make(map[string][]FileDoc)
+	}
+
+	return projectDoc, nil
+}
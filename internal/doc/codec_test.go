@@ -0,0 +1,58 @@
+package doc_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileDoc_RoundTrip(t *testing.T) {
+	projectDoc := doctest.Sample()
+
+	data, err := json.Marshal(projectDoc)
+	assert.NoError(t, err)
+
+	loaded, err := doc.LoadProjectDoc(strings.NewReader(string(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, projectDoc, *loaded)
+
+	parserDocs := loaded.PackageDocs["parser"][0].Docs
+	assert.IsType(t, &doc.StructDoc{}, parserDocs[0])
+	assert.IsType(t, &doc.FuncDoc{}, parserDocs[1])
+	assert.IsType(t, &doc.InterfaceDoc{}, loaded.PackageDocs["system"][0].Docs[0])
+}
+
+func TestFileDoc_MarshalUnknownType(t *testing.T) {
+	fileDoc := doc.FileDoc{
+		FileName: "main.go",
+		Path:     "main.go",
+		Docs: []doc.DocItem{
+			&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "main"}},
+		},
+	}
+
+	_, err := json.Marshal(fileDoc)
+	assert.Error(t, err)
+}
+
+func TestFileDoc_UnmarshalUnknownType(t *testing.T) {
+	var fileDoc doc.FileDoc
+	err := json.Unmarshal([]byte(`{"filename": "main.go", "path": "main.go", "docs": [{"name": "X", "type": "enum"}]}`), &fileDoc)
+	assert.Error(t, err)
+}
+
+func TestLoadProjectDoc_InvalidJSON(t *testing.T) {
+	projectDoc, err := doc.LoadProjectDoc(strings.NewReader("{"))
+	assert.Error(t, err)
+	assert.Nil(t, projectDoc)
+}
+
+func TestLoadProjectDoc_Empty(t *testing.T) {
+	projectDoc, err := doc.LoadProjectDoc(strings.NewReader("{}"))
+	assert.NoError(t, err)
+	assert.NotNil(t, projectDoc.PackageDocs)
+}
@@ -1,5 +1,28 @@
 package doc
 
+// Version of the serialized documentation format. Bump it whenever the doc types change.
+const SCHEMA_VERSION = "1.1.0"
+
+const (
+	FUNCTION_TYPE         = "function"
+	STRUCT_TYPE           = "struct"
+	INTERFACE_TYPE        = "interface"
+	INTERFACE_METHOD_TYPE = "interface-method"
+)
+
+/*
+@description Interface implemented by every documented item (functions, structs and interfaces). The Type of the BaseDoc is used as a discriminator when the documentation is serialized.
+@author Dorian TERBAH
+*/
+type DocItem interface {
+	/*
+		@description Retrieve the metadata shared by all documentation types
+		@author Dorian TERBAH
+		@return *BaseDoc - A pointer to the common metadata of the item
+	*/
+	GetBaseDoc() *BaseDoc
+}
+
 /*
 @description Struct to represent a function or struct field parameter in the documentation system.
 @author Dorian TERBAH
@@ -24,6 +47,21 @@ type Return struct {
 	Description string `json:"description"`
 }
 
+/*
+@description Struct to represent the location of a documented item in its source file. Lines and columns start at 1.
+@author Dorian TERBAH
+@field StartLine int - The line where the declaration starts
+@field StartColumn int - The column where the declaration starts
+@field EndLine int - The line where the declaration ends
+@field EndColumn int - The column where the declaration ends
+*/
+type Position struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
 /*
 @description Base struct shared by all documentation types, providing common metadata fields such as name, author, and description.@author
 @author Dorian TERBAH
@@ -32,13 +70,28 @@ type Return struct {
 @field Author string - The author of the item or its documentation
 @field Deprecated string - A deprecation message, if the item is deprecated
 @field Type string - The type of the documented item (e.g. 'function', 'struct')
+@field Position Position - The location of the declaration in its source file
+@field SourceLink string - A permalink to the declaration on the git forge, if a git link is configured
+@field Signature string - The Go declaration of the item, rendered from the source code (without function bodies nor comments)
 */
 type BaseDoc struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Author      string `json:"author"`
-	Deprecated  string `json:"deprecated"`
-	Type        string `json:"type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Author      string   `json:"author"`
+	Deprecated  string   `json:"deprecated"`
+	Type        string   `json:"type"`
+	Position    Position `json:"position"`
+	SourceLink  string   `json:"sourceLink,omitempty"`
+	Signature   string   `json:"signature,omitempty"`
+}
+
+/*
+@description Retrieve the metadata shared by all documentation types. Promoted to every doc type embedding BaseDoc, so that pointers to them implement DocItem.
+@return *BaseDoc - The base doc itself
+@author Dorian TERBAH
+*/
+func (baseDoc *BaseDoc) GetBaseDoc() *BaseDoc {
+	return baseDoc
 }
 
 /*
@@ -68,6 +121,11 @@ type StructDoc struct {
 	Fields []StructField `json:"fields"`
 }
 
+/*
+@description Struct to represent the documentation associated to a Go interface
+@author Dorian TERBAH
+@field Methods []FuncDoc - The documented methods of the interface
+*/
 type InterfaceDoc struct {
 	BaseDoc
 	Methods []FuncDoc `json:"methods,omitempty"`
@@ -78,20 +136,34 @@ type InterfaceDoc struct {
 @author Dorian TERBAH
 @field FileName string - The name of the file
 @field Path string - The full path to the file
-@field Docs []any - The documentation items contained in this file (functions, structs, etc.)
+@field Docs []DocItem - The documentation items contained in this file (functions, structs, etc.)
 */
 type FileDoc struct {
-	FileName string `json:"filename"`
-	Path     string `json:"path"`
-	// should be BaseDoc objects
-	Docs []any `json:"docs"`
+	FileName string    `json:"filename"`
+	Path     string    `json:"path"`
+	Docs     []DocItem `json:"docs"`
 }
 
 /*
 @description Struct to represent the entire documentation of a project, organized by package name and files within each package
 @author Dorian TERBAH
+@field SchemaVersion string - The version of the documentation format, see SCHEMA_VERSION
 @field PackageDocs map[string][]FileDoc - A mapping from package names to their documented files
 */
 type ProjectDoc struct {
-	PackageDocs map[string][]FileDoc `json:"packageDocs"`
+	SchemaVersion string               `json:"schemaVersion"`
+	PackageDocs   map[string][]FileDoc `json:"packageDocs"`
+}
+
+/*
+@description Create an empty project documentation using the current schema version
+@return *ProjectDoc - The empty documentation
+@example NewProjectDoc() => &ProjectDoc{SchemaVersion: "1.0.0", PackageDocs: {}}
+@author Dorian TERBAH
+*/
+func NewProjectDoc() *ProjectDoc {
+	return &ProjectDoc{
+		SchemaVersion: SCHEMA_VERSION,
+		PackageDocs:   make(map[string][]FileDoc),
+	}
 }
@@ -0,0 +1,112 @@
+// Package doctest builds the documentation fixtures shared by the tests of the other packages
+package doctest
+
+import (
+	"path"
+
+	"github.com/dterbah/zendoc/internal/doc"
+)
+
+/*
+@description Build the documentation of a project holding the single file internal/parser/parser.go
+@param items ...doc.DocItem - The documented items of the file
+@return doc.ProjectDoc - The documentation of the project
+@example Project(&doc.StructDoc{...})
+@author Dorian TERBAH
+*/
+func Project(items ...doc.DocItem) doc.ProjectDoc {
+	return ProjectFile("parser", "internal/parser/parser.go", items...)
+}
+
+/*
+@description Build the documentation of a project holding a single file
+@param pckName string - The package of the file
+@param filePath string - The slash-separated path of the file
+@param items ...doc.DocItem - The documented items of the file
+@return doc.ProjectDoc - The documentation of the project
+@example ProjectFile("system", "system/runner.go", &doc.FuncDoc{...})
+@author Dorian TERBAH
+*/
+func ProjectFile(pckName string, filePath string, items ...doc.DocItem) doc.ProjectDoc {
+	return doc.ProjectDoc{
+		PackageDocs: map[string][]doc.FileDoc{
+			pckName: {{FileName: path.Base(filePath), Path: filePath, Docs: items}},
+		},
+	}
+}
+
+/*
+@description Build a documentation covering every kind of item: a struct with its fields and a method, a deprecated function, an interface with its methods and a method name shared by two files of a package
+@return doc.ProjectDoc - The documentation of the parser, system and export packages
+@example Sample()
+@author Dorian TERBAH
+*/
+func Sample() doc.ProjectDoc {
+	return doc.ProjectDoc{
+		PackageDocs: map[string][]doc.FileDoc{
+			"parser": {
+				{
+					FileName: "parser.go",
+					Path:     "internal/parser/parser.go",
+					Docs: []doc.DocItem{
+						&doc.StructDoc{
+							BaseDoc: doc.BaseDoc{Name: "DocParser", Type: doc.STRUCT_TYPE, Description: "The <parser>", SourceLink: "https://github.com/a/b/blob/main/internal/parser/parser.go#L29-L32", Position: doc.Position{StartLine: 29}},
+							Fields:  []doc.StructField{{Name: "FileValidators", Type: "[]DocParserFileValidator", Description: "File validators"}},
+						},
+						&doc.FuncDoc{
+							BaseDoc: doc.BaseDoc{
+								Name:        "ParseDocForDir",
+								Type:        doc.FUNCTION_TYPE,
+								Description: "Recursively parse a directory",
+								Author:      "Dorian TERBAH",
+								Deprecated:  "Use ParseFS",
+								SourceLink:  "https://github.com/a/b/blob/main/internal/parser/parser.go#L74-L123",
+								Position:    doc.Position{StartLine: 74, StartColumn: 1, EndLine: 123, EndColumn: 2},
+							},
+							Params:  []doc.Param{{Name: "dirPath", Type: "string", Description: "The root | path"}},
+							Return:  &doc.Return{Type: "(*doc.ProjectDoc, error)", Description: "The documentation"},
+							Example: `ParseDocForDir("./myproject", "")`,
+							Struct:  "DocParser",
+						},
+						&doc.FuncDoc{
+							BaseDoc: doc.BaseDoc{Name: "getPackageName", Type: doc.FUNCTION_TYPE, Deprecated: "Use the AST", Position: doc.Position{StartLine: 130}},
+							Params:  []doc.Param{},
+						},
+					},
+				},
+			},
+			"system": {
+				{
+					FileName: "cmd.go",
+					Path:     "internal/system/cmd.go",
+					Docs: []doc.DocItem{
+						&doc.InterfaceDoc{
+							BaseDoc: doc.BaseDoc{Name: "CommandRunner", Type: doc.INTERFACE_TYPE, SourceLink: "https://github.com/a/b/blob/main/internal/system/cmd.go#L5-L18"},
+							Methods: []doc.FuncDoc{{BaseDoc: doc.BaseDoc{Name: "Execute", Type: doc.INTERFACE_METHOD_TYPE}, Params: []doc.Param{}}},
+						},
+					},
+				},
+			},
+			"export": {
+				{
+					FileName: "export.go",
+					Path:     "internal/export/export.go",
+					Docs: []doc.DocItem{
+						&doc.InterfaceDoc{
+							BaseDoc: doc.BaseDoc{Name: "DocExporter", Type: doc.INTERFACE_TYPE},
+							Methods: []doc.FuncDoc{{BaseDoc: doc.BaseDoc{Name: "Export", Type: doc.INTERFACE_METHOD_TYPE, Description: "Export the documentation"}, Params: []doc.Param{}}},
+						},
+					},
+				},
+				{
+					FileName: "html.go",
+					Path:     "internal/export/html.go",
+					Docs: []doc.DocItem{
+						&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "HTMLExporter", Type: doc.STRUCT_TYPE, Description: "Export a static site"}, Fields: []doc.StructField{}},
+						&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "Export", Type: doc.FUNCTION_TYPE}, Params: []doc.Param{}, Struct: "HTMLExporter"},
+					},
+				},
+			},
+		},
+	}
+}
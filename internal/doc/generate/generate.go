@@ -1,125 +1,435 @@
 package generate
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
+	"strings"
 
 	"github.com/dterbah/zendoc/config"
 	"github.com/dterbah/zendoc/internal"
+	"github.com/dterbah/zendoc/internal/doc"
 	"github.com/dterbah/zendoc/internal/export"
+	"github.com/dterbah/zendoc/internal/export/site"
+	"github.com/dterbah/zendoc/internal/export/source"
+	"github.com/dterbah/zendoc/internal/gitversion"
 	"github.com/dterbah/zendoc/internal/parser"
+	"github.com/dterbah/zendoc/internal/server"
 	"github.com/dterbah/zendoc/internal/system"
+	"github.com/dterbah/zendoc/pkg/zendoc"
 	"github.com/fatih/color"
 )
 
-func wrapFileValidator(condition bool, wrappedFunc parser.DocParserFileValidator) parser.DocParserFileValidator {
-	return func(filePath string) bool {
-		res := wrappedFunc(filePath)
-		if res {
-			return condition
-		}
+/*
+@description Struct to represent the options of a documentation generation
+@author Dorian TERBAH
+@field OutputFormat string - Either "json", "jsonl", "web", "markdown", "html", "template" or "sqlite", ignored when a plugin is used
+@field Plugin string - The name of an external exporter plugin, used instead of the output format when set
+@field Watch bool - Value used to watch the project modifications
+@field Changelog bool - Value used to write the changelog of the version, with the web output
+@field Version string - The version of the documentation, overriding the configured one when set. "auto" resolves it from the git tags
+@field Ref string - A git revision to document instead of the working tree
+@field AllTags bool - Value used to document every release tag of the repository
+@field Output string - With the json, jsonl and sqlite outputs, the path of the file, or "-" for the standard output (except for sqlite)
+@field Gzip bool - With the json and jsonl outputs, value used to compress the documentation with gzip
+@field Compact bool - With the json output, value used to write the JSON on a single line
+*/
+type GenerateOptions struct {
+	OutputFormat string
+	Plugin       string
+	Watch        bool
+	Changelog    bool
+	Version      string
+	Ref          string
+	AllTags      bool
+	Output       string
+	Gzip         bool
+	Compact      bool
+}
+
+/*
+@description Generate the documentation in a JSON format, in JSON Lines, in a web app, in Markdown files, in a static HTML site, in a SQLite database, through user templates or through an external plugin
+@param ctx context.Context - The context of the generation. Cancelling it stops the watch mode, and the external commands in progress.
+@param options GenerateOptions - The options of the generation
+@author Dorian TERBAH
+@return error - An error if the generation has failed
+*/
+func GenerateDoc(ctx context.Context, options GenerateOptions) error {
+	if options.Ref != "" || options.AllTags {
+		return generateRefs(ctx, options)
+	}
 
-		return true
+	projectConfig, err := LoadConfiguration(ctx, options.Version)
+	if err != nil {
+		return err
+	}
+
+	baseExporter, err := createExporter(options, *projectConfig, "")
+	if err != nil {
+		return err
 	}
-}
 
-func wrapFunctionValidator(condition bool, wrappedFunc parser.DocParserFunctionValidator) parser.DocParserFunctionValidator {
-	return func(name string) bool {
-		res := wrappedFunc(name)
-		if res {
-			return condition
+	docExporter := withSourceLinks(ctx, baseExporter, *projectConfig, "")
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		os.Exit(1)
+	}
+
+	if options.Watch {
+		docPath := filepath.Join(cwd, projectConfig.ProjectConfig.DocPath)
+		watcher := export.FileWatcher{
+			Exporter: docExporter,
 		}
-		return true
+		switch outputExporter := baseExporter.(type) {
+		case export.JSONExporter:
+			watcher.IgnoredFiles = outputExporter.OutputFiles()
+		case export.JSONLExporter:
+			watcher.IgnoredFiles = outputExporter.OutputFiles()
+		case export.SQLiteExporter:
+			watcher.IgnoredFiles = outputExporter.OutputFiles()
+		}
+		return watcher.WatchDir(ctx, createDocParser(*projectConfig), cwd, docPath)
 	}
-}
 
-func wrapRegexFileValidator(regex []string) parser.DocParserFileValidator {
-	return func(filePath string) bool {
-		for _, reg := range regex {
-			exp := regexp.MustCompile(reg)
-			// skip invalid regex
-			if exp != nil {
-				return !exp.MatchString(filePath)
-			}
+	// a streamed export receives each file as soon as it is parsed, instead of the whole project
+	if _, ok := baseExporter.(export.StreamExporter); ok {
+		docParser := createDocParser(*projectConfig)
+		stream := func(visit parser.FileDocVisitor) error {
+			return docParser.WalkDocForFS(ctx, os.DirFS(cwd), ".", "", visit)
 		}
-		return true
+		return docExporter.(export.StreamExporter).ExportStream(ctx, stream)
 	}
+
+	projectDoc, err := zendoc.Parse(ctx, zendoc.WithDir(cwd), zendoc.WithDocConfig(projectConfig.DocConfig), zendoc.WithProgress(true))
+	if err != nil {
+		color.Red("error when parse your project %s", err)
+		return err
+	}
+
+	return zendoc.Export(ctx, *projectDoc, docExporter)
 }
 
 /*
-@description Generate the documentation in a JSON format, or in a web app
-@param outputFormat string - Either "json" or "web"
-@param watch bool - Value used to watch the project modifications
+@description Serve the documentation of the project over HTTP, with a rendered HTML site, the raw JSON documentation and a live reload of the browsers when a file changes
+@param ctx context.Context - The context of the server, which is shut down when the context is done
+@param address string - The address the server listens on (e.g. "localhost:8080")
 @author Dorian TERBAH
-@return error - An error if the generation has failed
+@return error - An error if the parsing fails or if the server or the watcher stops, nil once the context is done
 */
-func GenerateDoc(outputFormat string, watch bool) error {
-	var docExporter export.DocExporter
+func ServeDoc(ctx context.Context, address string) error {
+	projectConfig, err := LoadConfiguration(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	docServer := server.NewServer(site.Options{
+		Title:       projectConfig.ProjectConfig.Name,
+		Description: projectConfig.ProjectConfig.Description,
+		Version:     projectConfig.ProjectConfig.Version,
+	})
+	docExporter := withSourceLinks(ctx, docServer, *projectConfig, "")
+	docParser := createDocParser(*projectConfig)
+
+	projectDoc, err := zendoc.Parse(ctx, zendoc.WithDir(cwd), zendoc.WithDocConfig(projectConfig.DocConfig), zendoc.WithProgress(true))
+	if err != nil {
+		color.Red("error when parse your project %s", err)
+		return err
+	}
+	if err := zendoc.Export(ctx, *projectDoc, docExporter); err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Addr:    address,
+		Handler: docServer.Handler(),
+		// the requests, such as the live reload streams, end with the context
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	errChan := make(chan error, 2)
+	go func() {
+		watcher := export.FileWatcher{
+			Exporter: docExporter,
+		}
+		errChan <- watcher.WatchDir(ctx, docParser, cwd, filepath.Join(cwd, projectConfig.ProjectConfig.DocPath))
+	}()
+	go func() {
+		color.Green("Serving the documentation on http://%s", address)
+		errChan <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), internal.SHUTDOWN_TIMEOUT)
+	defer cancel()
+	if shutdownErr := httpServer.Shutdown(shutdownCtx); err == nil && shutdownErr != nil {
+		return fmt.Errorf("error when stopping the server: %w", shutdownErr)
+	}
 
+	if err == nil {
+		color.HiYellow("Server stopped")
+	}
+	return err
+}
+
+/*
+@description Load the ZenDoc configuration, resolving the version of the documentation. When the version is "auto", it is resolved from the nearest git tag matching the tagPrefix of the configuration.
+@param ctx context.Context - The context of the git commands
+@param version string - The version overriding the configured one, or an empty string to keep it
+@return (*config.Config, error) - The configuration with a resolved version and an error if it cannot be read or the version cannot be resolved
+@example LoadConfiguration(ctx, "auto") => &config.Config{ProjectConfig: config.ProjectConfig{Version: "1.2.1-dev", ...}}, nil
+@author Dorian TERBAH
+*/
+func LoadConfiguration(ctx context.Context, version string) (*config.Config, error) {
 	projectConfig, err := config.GetConfiguration()
 	if err != nil {
-		return fmt.Errorf("error when reading the zendoc configuration : %s", err)
+		return nil, fmt.Errorf("error when reading the zendoc configuration : %s", err)
 	}
 
-	if outputFormat == internal.JSON_EXPORT_TYPE {
-		docExporter = export.JSONExporter{}
-	} else {
-		docExporter = export.WebExporter{
-			GitLink:     projectConfig.ProjectConfig.GitLink,
-			AppName:     projectConfig.ProjectConfig.Name,
-			MainBranch:  projectConfig.ProjectConfig.MainBranch,
-			DocPath:     projectConfig.ProjectConfig.DocPath,
-			Version:     projectConfig.ProjectConfig.Version,
-			Description: projectConfig.ProjectConfig.Description,
-			FileSystem:  system.OSFileSystem{},
-			CmdRunner:   system.OSCommandRunner{},
+	if version != "" {
+		projectConfig.ProjectConfig.Version = version
+	}
+
+	if projectConfig.ProjectConfig.Version == gitversion.AUTO_VERSION {
+		resolved, err := gitversion.Resolve(ctx, system.OSCommandRunner{}, "", projectConfig.ProjectConfig.TagPrefix, "")
+		if err != nil {
+			return nil, fmt.Errorf("error when resolving the version from git: %w", err)
 		}
+		projectConfig.ProjectConfig.Version = resolved
 	}
 
-	cwd, err := os.Getwd()
+	return projectConfig, nil
+}
+
+/*
+@description Parse the documentation of the project in the current directory, using the ZenDoc configuration, without printing the progress
+@param ctx context.Context - The context of the parsing
+@return (*doc.ProjectDoc, error) - The documentation of the project and an error if the configuration cannot be read or the parsing fails
+@author Dorian TERBAH
+*/
+func ParseProject(ctx context.Context) (*doc.ProjectDoc, error) {
+	projectConfig, err := config.GetConfiguration()
 	if err != nil {
-		os.Exit(1)
+		return nil, fmt.Errorf("error when reading the zendoc configuration : %s", err)
 	}
 
-	docParser := parser.DocParser{
-		FileValidators:     createFilevalidators(*projectConfig),
-		FunctionValidators: createFunctionsValidators(*projectConfig),
+	projectDoc, err := zendoc.Parse(ctx, zendoc.WithDocConfig(projectConfig.DocConfig))
+	if err != nil {
+		return nil, err
 	}
 
-	if watch {
-		docPath := filepath.Join(cwd, projectConfig.ProjectConfig.DocPath)
-		watcher := export.FileWatcher{
-			Exporter: docExporter,
+	if links, err := createLinkBuilder(ctx, *projectConfig, system.OSCommandRunner{}, ""); err == nil {
+		links.Annotate(projectDoc)
+	}
+
+	return projectDoc, nil
+}
+
+/*
+@description Load an exported documentation, given either the path of a JSON file or a version exported by the web output
+@param reference string - The path of a doc.json file, or a version such as "1.2.0"
+@return (*doc.ProjectDoc, error) - The documentation and an error if it cannot be found or read
+@author Dorian TERBAH
+*/
+func LoadDocumentation(reference string) (*doc.ProjectDoc, error) {
+	path := reference
+	if _, err := os.Stat(reference); err != nil {
+		projectConfig, err := config.GetConfiguration()
+		if err != nil {
+			return nil, fmt.Errorf("no file %s, and error when reading the zendoc configuration to find this version: %s", reference, err)
 		}
-		return watcher.WatchDir(docParser, cwd, docPath)
+		path = export.WebDocumentationFile(filepath.Join(projectConfig.ProjectConfig.DocPath, projectConfig.ProjectConfig.Name), reference)
 	}
 
-	projectDoc, err := docParser.ParseDocForDir(cwd, "")
+	file, err := os.Open(path)
 	if err != nil {
-		color.Red("error when parse your project %s", err)
-		return err
+		return nil, fmt.Errorf("no documentation found for %s: %w", reference, err)
+	}
+	defer file.Close()
+
+	return doc.LoadProjectDoc(file)
+}
+
+/*
+@description Wrap an exporter to add source links to the documentation, when a git link is configured
+@param ctx context.Context - The context of the git command resolving the current commit
+@param docExporter export.DocExporter - The exporter to wrap
+@param configuration config.Config - The ZenDoc configuration
+@param ref string - The git revision the links point to, or an empty string for the configured branch or the current commit
+@return export.DocExporter - The wrapped exporter, or the given one when the source links are disabled
+@author Dorian TERBAH
+*/
+func withSourceLinks(ctx context.Context, docExporter export.DocExporter, configuration config.Config, ref string) export.DocExporter {
+	links, err := createLinkBuilder(ctx, configuration, system.OSCommandRunner{}, ref)
+	if err != nil {
+		color.HiYellow("Source links disabled: %s", err)
+		return docExporter
+	}
+
+	return export.LinkedExporter{
+		Exporter: docExporter,
+		Links:    *links,
+	}
+}
+
+func createDocParser(configuration config.Config) parser.DocParser {
+	return parser.NewDocParser(configuration.DocConfig)
+}
+
+/*
+@description Create the exporter of the output format or of the plugin of the options
+@param options GenerateOptions - The options of the generation
+@param configuration config.Config - The ZenDoc configuration, with the version to export
+@param revision string - The git revision the documentation is generated from, HEAD when empty
+@return (export.DocExporter, error) - The exporter and an error if it cannot be configured
+@author Dorian TERBAH
+*/
+func createExporter(options GenerateOptions, configuration config.Config, revision string) (export.DocExporter, error) {
+	var docExporter export.DocExporter
+
+	switch {
+	case options.Plugin != "":
+		docExporter = export.PluginExporter{
+			Name:       options.Plugin,
+			Config:     configuration,
+			OutputDir:  configuration.ProjectConfig.DocPath,
+			FileSystem: system.OSFileSystem{},
+			CmdRunner:  system.OSCommandRunner{},
+		}
+	case options.OutputFormat == internal.JSON_EXPORT_TYPE:
+		docExporter = export.JSONExporter{
+			FileSystem: system.OSFileSystem{},
+			Output:     options.Output,
+			Stdout:     os.Stdout,
+			Gzip:       options.Gzip,
+			Compact:    options.Compact,
+		}
+	case options.OutputFormat == internal.JSONL_EXPORT_TYPE:
+		docExporter = export.JSONLExporter{
+			FileSystem: system.OSFileSystem{},
+			Output:     options.Output,
+			Stdout:     os.Stdout,
+			Gzip:       options.Gzip,
+		}
+	case options.OutputFormat == internal.MARKDOWN_EXPORT_TYPE:
+		docExporter = export.MarkdownExporter{
+			OutputDir:   filepath.Join(configuration.ProjectConfig.DocPath, export.MARKDOWN_DIR),
+			AppName:     configuration.ProjectConfig.Name,
+			Description: configuration.ProjectConfig.Description,
+			FileSystem:  system.OSFileSystem{},
+		}
+	case options.OutputFormat == internal.HTML_EXPORT_TYPE:
+		docExporter = export.HTMLExporter{
+			OutputDir:   filepath.Join(configuration.ProjectConfig.DocPath, export.HTML_DIR),
+			AppName:     configuration.ProjectConfig.Name,
+			Description: configuration.ProjectConfig.Description,
+			Version:     configuration.ProjectConfig.Version,
+			FileSystem:  system.OSFileSystem{},
+			CmdRunner:   system.OSCommandRunner{},
+			Revision:    revision,
+		}
+	case options.OutputFormat == internal.SQLITE_EXPORT_TYPE:
+		output := options.Output
+		if output == "" {
+			output = filepath.Join(configuration.ProjectConfig.DocPath, export.SQLITE_EXPORT_FILE)
+		}
+		docExporter = export.SQLiteExporter{
+			Output:    output,
+			Version:   configuration.ProjectConfig.Version,
+			CmdRunner: system.OSCommandRunner{},
+			Revision:  revision,
+		}
+	case options.OutputFormat == internal.TEMPLATE_EXPORT_TYPE:
+		return createTemplateExporter(configuration)
+	default:
+		docExporter = export.WebExporter{
+			GitLink:     configuration.ProjectConfig.GitLink,
+			AppName:     configuration.ProjectConfig.Name,
+			MainBranch:  configuration.ProjectConfig.MainBranch,
+			DocPath:     configuration.ProjectConfig.DocPath,
+			Version:     configuration.ProjectConfig.Version,
+			Description: configuration.ProjectConfig.Description,
+			FileSystem:  system.OSFileSystem{},
+			CmdRunner:   system.OSCommandRunner{},
+			Changelog:   options.Changelog,
+			Revision:    revision,
+		}
 	}
 
-	return docExporter.Export(*projectDoc)
+	return docExporter, nil
 }
 
-func createFilevalidators(configuration config.Config) []parser.DocParserFileValidator {
-	validators := []parser.DocParserFileValidator{}
+/*
+@description Create the exporter rendering the user templates from the template configuration
+@param configuration config.Config - The ZenDoc configuration
+@return (export.DocExporter, error) - The template exporter and an error if no template directory is configured
+@author Dorian TERBAH
+*/
+func createTemplateExporter(configuration config.Config) (export.DocExporter, error) {
+	templateConfig := configuration.TemplateConfig
+	if templateConfig.Dir == "" {
+		return nil, fmt.Errorf("missing \"dir\" in the \"templateConfig\" section of the configuration")
+	}
 
-	validators = append(validators, wrapFileValidator(configuration.DocConfig.IncludeTests, IsTestFile),
-		wrapFileValidator(configuration.DocConfig.IncludeMain, IsMainFile),
-		wrapRegexFileValidator(configuration.DocConfig.ExcludeFiles),
-	)
+	outputDir := templateConfig.OutputDir
+	if outputDir == "" {
+		outputDir = filepath.Join(configuration.ProjectConfig.DocPath, export.TEMPLATE_DIR)
+	}
 
-	return validators
+	return export.TemplateExporter{
+		Templates:   os.DirFS(templateConfig.Dir),
+		OutputDir:   outputDir,
+		Engine:      templateConfig.Engine,
+		AppName:     configuration.ProjectConfig.Name,
+		Description: configuration.ProjectConfig.Description,
+		Version:     configuration.ProjectConfig.Version,
+		FileSystem:  system.OSFileSystem{},
+	}, nil
 }
 
-func createFunctionsValidators(configuration config.Config) []parser.DocParserFunctionValidator {
-	validators := []parser.DocParserFunctionValidator{}
+/*
+@description Create the builder of the source links from the project configuration
+@param ctx context.Context - The context of the git command
+@param configuration config.Config - The ZenDoc configuration
+@param cmdRunner system.CommandRunner - The runner used to resolve the current commit when linkToCommit is enabled
+@param ref string - The git revision the links point to, used instead of the configured branch and of linkToCommit when set
+@return (*source.LinkBuilder, error) - The link builder and an error if no valid git link is configured
+@author Dorian TERBAH
+*/
+func createLinkBuilder(ctx context.Context, configuration config.Config, cmdRunner system.CommandRunner, ref string) (*source.LinkBuilder, error) {
+	projectConfig := configuration.ProjectConfig
+	if projectConfig.GitLink == "" {
+		return nil, fmt.Errorf("no git link configured")
+	}
+
+	if ref != "" {
+		return source.NewLinkBuilder(projectConfig.GitLink, projectConfig.GitProvider, ref, false)
+	}
 
-	validators = append(validators, wrapFunctionValidator(configuration.DocConfig.IncludePrivate, IsPrivateFunction))
+	ref = projectConfig.MainBranch
+	if ref == "" {
+		ref = "main"
+	}
+
+	if projectConfig.LinkToCommit {
+		output, err := cmdRunner.Execute(ctx, "", "git", "rev-parse", "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("error when resolving the current commit: %s", err)
+		}
+		return source.NewLinkBuilder(projectConfig.GitLink, projectConfig.GitProvider, strings.TrimSpace(string(output)), true)
+	}
 
-	return validators
+	return source.NewLinkBuilder(projectConfig.GitLink, projectConfig.GitProvider, ref, false)
 }
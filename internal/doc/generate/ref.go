@@ -0,0 +1,219 @@
+package generate
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/dterbah/zendoc/config"
+	"github.com/dterbah/zendoc/internal/apicheck"
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/gitversion"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
+)
+
+/*
+@description Read the exported API of the project as it was at a git ref, from all its exported declarations whether they are documented or not
+@param ctx context.Context - The context of the git commands and of the parsing
+@param ref string - The git ref, such as a tag, a branch or a commit
+@return (*doc.ProjectDoc, string, error) - The API, the version of the project configured at this ref (empty if the ref has no ZenDoc configuration) and an error if the ref cannot be read or parsed
+@example ParseRefAPI(ctx, "v1.2.0") => &doc.ProjectDoc{...}, "1.2.0", nil
+@author Dorian TERBAH
+*/
+func ParseRefAPI(ctx context.Context, ref string) (*doc.ProjectDoc, string, error) {
+	projectConfig, err := config.GetConfiguration()
+	if err != nil {
+		return nil, "", fmt.Errorf("error when reading the zendoc configuration : %s", err)
+	}
+
+	fsys, err := extractRef(ctx, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	api, err := apicheck.ParseAPI(ctx, fsys, ".")
+	if err != nil {
+		return nil, "", fmt.Errorf("error when parsing %s: %w", ref, err)
+	}
+
+	version := refVersion(fsys)
+	if version == gitversion.AUTO_VERSION {
+		version, err = gitversion.Resolve(ctx, system.OSCommandRunner{}, "", projectConfig.ProjectConfig.TagPrefix, ref)
+		if err != nil {
+			return nil, "", fmt.Errorf("error when resolving the version of %s: %w", ref, err)
+		}
+	}
+
+	return api, version, nil
+}
+
+// generateRefs generates the documentation of git revisions, the given ref or every release tag, without touching the working tree
+func generateRefs(ctx context.Context, options GenerateOptions) error {
+	if options.Watch {
+		return fmt.Errorf("the watch mode cannot be used with a git ref")
+	}
+
+	projectConfig, err := config.GetConfiguration()
+	if err != nil {
+		return fmt.Errorf("error when reading the zendoc configuration : %s", err)
+	}
+
+	cmdRunner := system.OSCommandRunner{}
+	tagPrefix := projectConfig.ProjectConfig.TagPrefix
+
+	refs := []string{options.Ref}
+	if options.AllTags {
+		if options.Ref != "" || options.Version != "" {
+			return fmt.Errorf("--all-tags cannot be used with a ref or a version")
+		}
+
+		refs, err = gitversion.Tags(ctx, cmdRunner, "", tagPrefix)
+		if err != nil {
+			return err
+		}
+		if len(refs) == 0 {
+			return fmt.Errorf("no release tag found with the prefix \"%s\"", tagPrefix)
+		}
+	}
+
+	for _, ref := range refs {
+		version := options.Version
+		if version == "" || version == gitversion.AUTO_VERSION {
+			version, err = gitversion.Resolve(ctx, cmdRunner, "", tagPrefix, ref)
+			if err != nil {
+				return fmt.Errorf("error when resolving the version of %s: %w", ref, err)
+			}
+		}
+
+		refConfig := *projectConfig
+		refConfig.ProjectConfig.Version = version
+
+		docExporter, err := createExporter(options, refConfig, ref)
+		if err != nil {
+			return err
+		}
+		// only the source links point to the documented revision, the main branch of the web app is shared by every version
+		docExporter = withSourceLinks(ctx, docExporter, refConfig, ref)
+
+		color.Cyan("Generating the documentation v%s from %s", version, ref)
+		projectDoc, err := parseRef(ctx, refConfig, ref)
+		if err != nil {
+			return err
+		}
+
+		if err := docExporter.Export(ctx, *projectDoc); err != nil {
+			return fmt.Errorf("error when exporting the documentation of %s: %w", ref, err)
+		}
+	}
+
+	return nil
+}
+
+// parseRef parses the code of a git ref extracted in memory
+func parseRef(ctx context.Context, projectConfig config.Config, ref string) (*doc.ProjectDoc, error) {
+	fsys, err := extractRef(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	docParser := createDocParser(projectConfig)
+	docParser.Quiet = true
+	projectDoc, err := docParser.ParseDocForFS(ctx, fsys, ".", "")
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing %s: %w", ref, err)
+	}
+
+	return projectDoc, nil
+}
+
+// extractRef extracts the code of a git ref in memory
+func extractRef(ctx context.Context, ref string) (*system.MemoryFileSystem, error) {
+	archive, err := archiveRef(ctx, system.OSCommandRunner{}, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	fsys := system.NewMemoryFileSystem()
+	if err := extractArchive(bytes.NewReader(archive), fsys); err != nil {
+		return nil, fmt.Errorf("error when extracting %s: %w", ref, err)
+	}
+	return fsys, nil
+}
+
+// archiveRef exports, as a tar archive, the content of the current directory at the given ref
+func archiveRef(ctx context.Context, cmdRunner system.CommandRunner, ref string) ([]byte, error) {
+	output, err := cmdRunner.Execute(ctx, "", "git", "rev-parse", "--show-toplevel", "--show-prefix")
+	if err != nil {
+		return nil, fmt.Errorf("error when locating the git repository: %s", strings.TrimSpace(string(output)))
+	}
+
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	topLevel, prefix := lines[0], ""
+	if len(lines) > 1 {
+		prefix = strings.TrimSuffix(lines[1], "/")
+	}
+
+	treeish := ref
+	if prefix != "" {
+		treeish = ref + ":" + prefix
+	}
+
+	archive, err := cmdRunner.ExecuteWithInput(ctx, topLevel, nil, "git", "archive", "--format=tar", treeish)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading the git ref %s: %w", ref, err)
+	}
+	return archive, nil
+}
+
+// extractArchive writes the directories and regular files of a tar archive to the given file system
+func extractArchive(reader io.Reader, fileSystem system.FileSystem) error {
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid path %s in the archive", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := fileSystem.MkdirAll(name, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := fileSystem.MkdirAll(filepath.Dir(name), 0755); err != nil {
+				return err
+			}
+			content, err := io.ReadAll(archive)
+			if err != nil {
+				return err
+			}
+			if err := fileSystem.WriteFile(name, content, 0644); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// refVersion reads the version of the project from the ZenDoc configuration of an extracted ref
+func refVersion(fsys fs.FS) string {
+	refConfig, err := config.LoadConfiguration(fsys)
+	if err != nil {
+		return ""
+	}
+	return refConfig.ProjectConfig.Version
+}
@@ -0,0 +1,89 @@
+package generate
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dterbah/zendoc/config"
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeGitRunner struct {
+	output   string
+	dir      string
+	commands [][]string
+}
+
+func (r *fakeGitRunner) Execute(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	r.commands = append(r.commands, append([]string{name}, args...))
+	return []byte(r.output), nil
+}
+
+func (r *fakeGitRunner) ExecuteWithInput(ctx context.Context, dir string, input []byte, name string, args ...string) ([]byte, error) {
+	r.dir = dir
+	r.commands = append(r.commands, append([]string{name}, args...))
+	return []byte("archive"), nil
+}
+
+func TestArchiveRef(t *testing.T) {
+	runner := &fakeGitRunner{output: "/repo\ntools/zendoc/\n"}
+	archive, err := archiveRef(context.Background(), runner, "v1.0.0")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "archive", string(archive))
+	assert.Equal(t, "/repo", runner.dir)
+	assert.Equal(t, []string{"git", "archive", "--format=tar", "v1.0.0:tools/zendoc"}, runner.commands[1])
+
+	runner = &fakeGitRunner{output: "/repo\n\n"}
+	_, err = archiveRef(context.Background(), runner, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"git", "archive", "--format=tar", "main"}, runner.commands[1])
+}
+
+func writeArchive(t *testing.T, files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	for name, content := range files {
+		assert.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := writer.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+	return &buf
+}
+
+func TestExtractArchive(t *testing.T) {
+	fsys := system.NewMemoryFileSystem()
+	archive := writeArchive(t, map[string]string{
+		".zendoc.config.json": `{"projectConfig": {"version": "1.2.0"}}`,
+		"internal/main.go":    "package main",
+	})
+
+	assert.NoError(t, extractArchive(archive, fsys))
+
+	content, err := fsys.ReadFile("internal/main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package main", string(content))
+	assert.Equal(t, "1.2.0", refVersion(fsys))
+	assert.Equal(t, "", refVersion(system.NewMemoryFileSystem()))
+
+	assert.ErrorContains(t, extractArchive(writeArchive(t, map[string]string{"../evil.go": "package evil"}), fsys), "invalid path")
+}
+
+func TestCreateLinkBuilder_Ref(t *testing.T) {
+	configuration := config.Config{ProjectConfig: config.ProjectConfig{GitLink: "https://github.com/a/b", MainBranch: "develop", LinkToCommit: true}}
+
+	links, err := createLinkBuilder(context.Background(), configuration, nil, "v1.3.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/a/b/blob/v1.3.0/parser.go#L3", links.Link("parser.go", doc.Position{StartLine: 3}))
+
+	// the exporter of a ref keeps the main branch shared by every version of the web app
+	docExporter, err := createExporter(GenerateOptions{OutputFormat: "web"}, configuration, "v1.3.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "develop", docExporter.(export.WebExporter).MainBranch)
+}
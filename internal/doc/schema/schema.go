@@ -0,0 +1,187 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+)
+
+const JSON_SCHEMA_DRAFT = "https://json-schema.org/draft/2020-12/schema"
+const SCHEMA_ID = "https://github.com/ZenDocLabs/zendoc/schema/doc.schema.json"
+
+var docItemType = reflect.TypeOf((*doc.DocItem)(nil)).Elem()
+
+// concrete kinds of DocItem, with the values of their "type" discriminator
+var docItemKinds = []struct {
+	item  doc.DocItem
+	types []string
+}{
+	{&doc.FuncDoc{}, []string{doc.FUNCTION_TYPE, doc.INTERFACE_METHOD_TYPE}},
+	{&doc.StructDoc{}, []string{doc.STRUCT_TYPE}},
+	{&doc.InterfaceDoc{}, []string{doc.INTERFACE_TYPE}},
+}
+
+/*
+@description Struct responsible for building a JSON Schema from Go types by reflection, following the encoding/json rules
+@author Dorian TERBAH
+@field definitions map[string]any - The schemas of the named struct types, referenced through "$defs"
+*/
+type generator struct {
+	definitions map[string]any
+}
+
+/*
+@description Generate the JSON Schema of the documentation format (doc.json and doc-<version>.json files) from the internal/doc types
+@return map[string]any - The JSON Schema, ready to be serialized
+@example Generate() => map[string]any{"$schema": "https://json-schema.org/draft/2020-12/schema", ...}
+@author Dorian TERBAH
+*/
+func Generate() map[string]any {
+	g := generator{definitions: map[string]any{}}
+
+	root := g.structSchema(reflect.TypeOf(doc.ProjectDoc{}))
+	root["$schema"] = JSON_SCHEMA_DRAFT
+	root["$id"] = SCHEMA_ID
+	root["title"] = "ZenDoc documentation"
+	root["properties"].(map[string]any)["schemaVersion"] = map[string]any{
+		"const": doc.SCHEMA_VERSION,
+	}
+	root["$defs"] = g.definitions
+
+	return root
+}
+
+/*
+@description Generate the JSON Schema of the documentation format and serialize it
+@return ([]byte, error) - The indented JSON Schema and an error if the serialization fails
+@author Dorian TERBAH
+*/
+func GenerateJSON() ([]byte, error) {
+	content, err := json.MarshalIndent(Generate(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error when serializing the JSON schema: %w", err)
+	}
+
+	return append(content, '\n'), nil
+}
+
+// typeSchema returns the schema of any Go type
+func (g generator) typeSchema(t reflect.Type) map[string]any {
+	if t == docItemType {
+		return g.docItemSchema()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		// nil pointers are serialized as null
+		return map[string]any{
+			"anyOf": []any{g.typeSchema(t.Elem()), map[string]any{"type": "null"}},
+		}
+	case reflect.Struct:
+		return g.ref(t)
+	case reflect.Slice, reflect.Array:
+		// nil slices are serialized as null
+		return map[string]any{
+			"type":  []any{"array", "null"},
+			"items": g.typeSchema(t.Elem()),
+		}
+	case reflect.Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": g.typeSchema(t.Elem()),
+		}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+
+	return map[string]any{}
+}
+
+// ref registers the definition of a named struct and returns a reference to it
+func (g generator) ref(t reflect.Type) map[string]any {
+	if _, ok := g.definitions[t.Name()]; !ok {
+		// placeholder to stop recursion on self-referencing types
+		g.definitions[t.Name()] = nil
+		g.definitions[t.Name()] = g.structSchema(t)
+	}
+
+	return map[string]any{"$ref": "#/$defs/" + t.Name()}
+}
+
+// structSchema returns the object schema of a struct, inlining embedded structs like encoding/json does
+func (g generator) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+
+	g.collectFields(t, properties, &required)
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (g generator) collectFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			g.collectFields(field.Type, properties, required)
+			continue
+		}
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = g.typeSchema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// docItemSchema returns the union of the doc kinds, discriminated by their "type" property
+func (g generator) docItemSchema() map[string]any {
+	variants := []any{}
+
+	for _, kind := range docItemKinds {
+		itemType := reflect.TypeOf(kind.item).Elem()
+		g.ref(itemType)
+
+		types := []any{}
+		for _, t := range kind.types {
+			types = append(types, t)
+		}
+
+		variants = append(variants, map[string]any{
+			"allOf": []any{
+				map[string]any{"$ref": "#/$defs/" + itemType.Name()},
+				map[string]any{
+					"properties": map[string]any{
+						"type": map[string]any{"enum": types},
+					},
+				},
+			},
+		})
+	}
+
+	return map[string]any{"oneOf": variants}
+}
@@ -0,0 +1,105 @@
+package schema_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/schema"
+	"github.com/dterbah/zendoc/internal/parser"
+	"github.com/dterbah/zendoc/internal/parser/serializer"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/assert"
+)
+
+const PUBLISHED_SCHEMA = "../../../documentation/schema/doc.schema.json"
+
+func compileSchema(t *testing.T) *jsonschema.Schema {
+	t.Helper()
+	content, err := schema.GenerateJSON()
+	assert.NoError(t, err)
+
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(content))
+	assert.NoError(t, err)
+
+	compiler := jsonschema.NewCompiler()
+	assert.NoError(t, compiler.AddResource(schema.SCHEMA_ID, schemaDoc))
+
+	compiled, err := compiler.Compile(schema.SCHEMA_ID)
+	assert.NoError(t, err)
+	return compiled
+}
+
+func validate(t *testing.T, compiled *jsonschema.Schema, content string) error {
+	t.Helper()
+	instance, err := jsonschema.UnmarshalJSON(strings.NewReader(content))
+	assert.NoError(t, err)
+	return compiled.Validate(instance)
+}
+
+func TestSerializeToJSON_MatchesSchema(t *testing.T) {
+	compiled := compileSchema(t)
+
+	projectDoc := doc.ProjectDoc{
+		PackageDocs: map[string][]doc.FileDoc{
+			"system": {
+				{
+					FileName: "cmd.go",
+					Path:     "internal/system/cmd.go",
+					Docs: []doc.DocItem{
+						&doc.FuncDoc{
+							BaseDoc: doc.BaseDoc{Name: "Execute", Type: doc.FUNCTION_TYPE, SourceLink: "https://github.com/a/b"},
+							Params:  []doc.Param{{Name: "dir", Type: "string", Description: "The directory"}},
+							Return:  &doc.Return{Type: "error", Description: "An error"},
+						},
+						&doc.StructDoc{
+							BaseDoc: doc.BaseDoc{Name: "OSFileSystem", Type: doc.STRUCT_TYPE},
+						},
+						&doc.InterfaceDoc{
+							BaseDoc: doc.BaseDoc{Name: "CommandRunner", Type: doc.INTERFACE_TYPE},
+							Methods: []doc.FuncDoc{
+								{BaseDoc: doc.BaseDoc{Name: "Execute", Type: doc.INTERFACE_METHOD_TYPE}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	content, err := serializer.SerializeToJSON(projectDoc)
+	assert.NoError(t, err)
+	assert.NoError(t, validate(t, compiled, content))
+}
+
+func TestSerializeToJSON_ProjectMatchesSchema(t *testing.T) {
+	compiled := compileSchema(t)
+
+	projectDoc, err := parser.DocParser{}.ParseDocForDir(context.Background(), "../../..", "")
+	assert.NoError(t, err)
+	assert.Equal(t, doc.SCHEMA_VERSION, projectDoc.SchemaVersion)
+
+	content, err := serializer.SerializeToJSON(*projectDoc)
+	assert.NoError(t, err)
+	assert.NoError(t, validate(t, compiled, content))
+}
+
+func TestSchema_RejectsInvalidDocuments(t *testing.T) {
+	compiled := compileSchema(t)
+
+	assert.Error(t, validate(t, compiled, `{"schemaVersion": "0.0.1", "packageDocs": {}}`))
+	assert.Error(t, validate(t, compiled, `{"schemaVersion": "`+doc.SCHEMA_VERSION+`"}`))
+	assert.Error(t, validate(t, compiled, `{"schemaVersion": "`+doc.SCHEMA_VERSION+`", "packageDocs": {"main": [{"filename": "main.go", "path": "main.go", "docs": [{"type": "enum"}]}]}}`))
+}
+
+func TestSchema_PublishedFileIsUpToDate(t *testing.T) {
+	published, err := os.ReadFile(PUBLISHED_SCHEMA)
+	assert.NoError(t, err)
+
+	content, err := schema.GenerateJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, string(content), string(published), "run `zendoc schema -o documentation/schema/doc.schema.json` to update the published schema")
+}
@@ -0,0 +1,107 @@
+package doc
+
+import (
+	"sort"
+	"strings"
+)
+
+/*
+@description Struct to represent a documented symbol with its location in the project, as a flat view of a ProjectDoc
+@author Dorian TERBAH
+@field ID string - The unique identifier of the symbol: its package and its qualified name (e.g. parser.DocParser.ParseDocForDir)
+@field Name string - The qualified name of the symbol, prefixed by its struct or interface for methods
+@field Package string - The package declaring the symbol
+@field File FileDoc - The file declaring the symbol
+@field Item DocItem - The documented item
+*/
+type Symbol struct {
+	ID      string
+	Name    string
+	Package string
+	File    FileDoc
+	Item    DocItem
+}
+
+/*
+@description Flatten a project documentation into the list of its symbols, interface methods included, sorted by identifier
+@param projectDoc ProjectDoc - The project documentation
+@return []Symbol - The symbols of the project
+@example Symbols(projectDoc) => [{ID: "parser.DocParser", ...}, {ID: "parser.DocParser.ParseDocForDir", ...}]
+@author Dorian TERBAH
+*/
+func Symbols(projectDoc ProjectDoc) []Symbol {
+	symbols := []Symbol{}
+
+	for pckName, files := range projectDoc.PackageDocs {
+		for _, file := range files {
+			symbols = append(symbols, FileSymbols(pckName, file)...)
+		}
+	}
+
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return symbols[i].ID < symbols[j].ID
+	})
+
+	return symbols
+}
+
+/*
+@description List the symbols of a single file, interface methods included, in their order of declaration
+@param pckName string - The package of the file
+@param file FileDoc - The documentation of the file
+@return []Symbol - The symbols of the file
+@example FileSymbols("parser", fileDoc) => [{ID: "parser.DocParser", ...}, {ID: "parser.DocParser.ParseDocForDir", ...}]
+@author Dorian TERBAH
+*/
+func FileSymbols(pckName string, file FileDoc) []Symbol {
+	symbols := []Symbol{}
+
+	for _, item := range file.Docs {
+		name := item.GetBaseDoc().Name
+		if fd, ok := item.(*FuncDoc); ok && fd.Struct != "" {
+			name = fd.Struct + "." + fd.Name
+		}
+		symbols = append(symbols, newSymbol(pckName, name, file, item))
+
+		if id, ok := item.(*InterfaceDoc); ok {
+			for i := range id.Methods {
+				method := &id.Methods[i]
+				symbols = append(symbols, newSymbol(pckName, id.Name+"."+method.Name, file, method))
+			}
+		}
+	}
+
+	return symbols
+}
+
+/*
+@description Find a symbol by its identifier, ignoring the case when no symbol matches exactly
+@param symbols []Symbol - The symbols to search
+@param id string - The identifier of the symbol (e.g. parser.DocParser.ParseDocForDir)
+@return (Symbol, bool) - The symbol and true if it was found
+@author Dorian TERBAH
+*/
+func FindSymbol(symbols []Symbol, id string) (Symbol, bool) {
+	for _, symbol := range symbols {
+		if symbol.ID == id {
+			return symbol, true
+		}
+	}
+	for _, symbol := range symbols {
+		if strings.EqualFold(symbol.ID, id) {
+			return symbol, true
+		}
+	}
+
+	return Symbol{}, false
+}
+
+func newSymbol(pckName, name string, file FileDoc, item DocItem) Symbol {
+	return Symbol{
+		ID:      pckName + "." + name,
+		Name:    name,
+		Package: pckName,
+		File:    file,
+		Item:    item,
+	}
+}
@@ -0,0 +1,50 @@
+package doc_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymbols(t *testing.T) {
+	symbols := doc.Symbols(doctest.Sample())
+
+	ids := []string{}
+	for _, symbol := range symbols {
+		ids = append(ids, symbol.ID)
+	}
+	assert.Equal(t, []string{
+		"export.DocExporter",
+		"export.DocExporter.Export",
+		"export.HTMLExporter",
+		"export.HTMLExporter.Export",
+		"parser.DocParser",
+		"parser.DocParser.ParseDocForDir",
+		"parser.getPackageName",
+		"system.CommandRunner",
+		"system.CommandRunner.Execute",
+	}, ids)
+
+	method := symbols[8]
+	assert.Equal(t, "CommandRunner.Execute", method.Name)
+	assert.Equal(t, "system", method.Package)
+	assert.Equal(t, "internal/system/cmd.go", method.File.Path)
+	assert.Equal(t, doc.INTERFACE_METHOD_TYPE, method.Item.GetBaseDoc().Type)
+}
+
+func TestFindSymbol(t *testing.T) {
+	symbols := doc.Symbols(doctest.Sample())
+
+	symbol, ok := doc.FindSymbol(symbols, "parser.DocParser.ParseDocForDir")
+	assert.True(t, ok)
+	assert.Equal(t, "ParseDocForDir", symbol.Item.GetBaseDoc().Name)
+
+	symbol, ok = doc.FindSymbol(symbols, "PARSER.docparser")
+	assert.True(t, ok)
+	assert.Equal(t, "parser.DocParser", symbol.ID)
+
+	_, ok = doc.FindSymbol(symbols, "parser.Unknown")
+	assert.False(t, ok)
+}
@@ -3,65 +3,127 @@ package app
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 
-	"slices"
-
-	"github.com/dterbah/zendoc/internal/export/helper"
+	"github.com/dterbah/zendoc/internal/system"
 )
 
+/*
+@description Struct to represent the app.json file listing the versions of a documentation
+@author Dorian TERBAH
+@field Versions []VersionEntry - The documented versions with their metadata, sorted by semantic version
+@field Description string - The description of the project
+@field Latest string - The version shown by default
+@field Next string - The version of the next/dev channel, if any
+*/
 type AppConfig struct {
-	Versions    []string `json:"versions"`
-	Description string   `json:"description"`
+	Versions    []VersionEntry `json:"versions"`
+	Description string         `json:"description"`
+	Latest      string         `json:"latest,omitempty"`
+	Next        string         `json:"next,omitempty"`
 }
 
-func UpdateAppConfig(appPath, currentVersion, description string) error {
-	if !helper.IsFileExist(appPath) {
+/*
+@description Record a version in the app.json file, creating the file if needed. The metadata of an already recorded version are replaced.
+@param fileSystem system.FileSystem - The file system holding the app.json file
+@param appPath string - The path of the app.json file
+@param entry VersionEntry - The version and its metadata
+@param description string - The description of the project, used when the file is created
+@return error - An error if the file cannot be read or saved
+@author Dorian TERBAH
+*/
+func UpdateAppConfig(fileSystem system.FileSystem, appPath string, entry VersionEntry, description string) error {
+	config, err := AppConfigWithVersion(fileSystem, appPath, entry, description)
+	if err != nil {
+		return err
+	}
+
+	return saveAppConfig(fileSystem, appPath, *config)
+}
+
+/*
+@description Compute the content of the app.json file once a version is recorded, without saving it, so that it can be written with the other files of an export
+@param fileSystem system.FileSystem - The file system holding the app.json file
+@param appPath string - The path of the app.json file, which may not exist yet
+@param entry VersionEntry - The version and its metadata
+@param description string - The description of the project, used when the file does not exist
+@return (*AppConfig, error) - The updated configuration and an error if the existing file cannot be read
+@author Dorian TERBAH
+*/
+func AppConfigWithVersion(fileSystem system.FileSystem, appPath string, entry VersionEntry, description string) (*AppConfig, error) {
+	if !fileSystem.FileExists(appPath) {
 		config := AppConfig{
-			Versions:    []string{currentVersion},
 			Description: description,
 		}
+		config.AddVersion(entry)
 
-		return saveAppConfig(appPath, config)
+		return &config, nil
 	}
 
-	data, err := os.ReadFile(appPath)
+	config, err := LoadAppConfig(fileSystem, appPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("version file does not exist: %w", err)
-		}
-		return fmt.Errorf("error reading version file: %w", err)
+		return nil, err
 	}
 
-	var config AppConfig
+	config.AddVersion(entry)
 
-	err = json.Unmarshal(data, &config)
+	return config, nil
+}
+
+/*
+@description Load the app configuration holding the versions of the documentation
+@param fileSystem system.FileSystem - The file system holding the app.json file
+@param appPath string - The path of the app.json file
+@return (*AppConfig, error) - The loaded configuration and an error if the file cannot be read
+@author Dorian TERBAH
+*/
+func LoadAppConfig(fileSystem system.FileSystem, appPath string) (*AppConfig, error) {
+	data, err := fileSystem.ReadFile(appPath)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("error reading version file: %w", err)
 	}
 
-	updateVersion := true
-	if slices.Contains(config.Versions, currentVersion) {
-		updateVersion = false
+	var config AppConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
 	}
 
-	if updateVersion {
-		config.Versions = append(config.Versions, currentVersion)
+	return &config, nil
+}
+
+/*
+@description List the names of the recorded versions
+@return []string - The versions, sorted as in the file
+@author Dorian TERBAH
+*/
+func (config AppConfig) VersionNames() []string {
+	names := make([]string, 0, len(config.Versions))
+	for _, entry := range config.Versions {
+		names = append(names, entry.Version)
 	}
+	return names
+}
 
-	return saveAppConfig(appPath, config)
+/*
+@description Serialize the app configuration as the content of the app.json file
+@return ([]byte, error) - The indented JSON content and an error if the serialization fails
+@author Dorian TERBAH
+*/
+func (config AppConfig) Marshal() ([]byte, error) {
+	return json.MarshalIndent(config, "", "  ")
 }
 
-func saveAppConfig(appPath string, config AppConfig) error {
-	data, err := json.MarshalIndent(config, "", "  ")
+func saveAppConfig(fileSystem system.FileSystem, appPath string, config AppConfig) error {
+	data, err := config.Marshal()
 	if err != nil {
 		return err
 	}
 
-	err = os.WriteFile(appPath, data, 0644)
-	if err != nil {
+	// the previous file stays in place when the new one cannot be written completely
+	transaction := system.NewTransaction(fileSystem)
+	defer transaction.Rollback()
+	if err := transaction.WriteFile(appPath, data, 0644); err != nil {
 		return err
 	}
 
-	return nil
+	return transaction.Commit()
 }
@@ -1,12 +1,16 @@
 package app_test
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/dterbah/zendoc/internal/doc"
 	"github.com/dterbah/zendoc/internal/export/app"
+	"github.com/dterbah/zendoc/internal/system"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,7 +18,7 @@ func TestUpdateAppConfig_NewFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	filePath := filepath.Join(tmpDir, "VERSION.json")
 
-	err := app.UpdateAppConfig(filePath, "v1.0.0", "Initial release")
+	err := app.UpdateAppConfig(system.OSFileSystem{}, filePath, app.VersionEntry{Version: "v1.0.0"}, "Initial release")
 	assert.NoError(t, err)
 
 	data, err := os.ReadFile(filePath)
@@ -24,7 +28,7 @@ func TestUpdateAppConfig_NewFile(t *testing.T) {
 	err = json.Unmarshal(data, &config)
 	assert.NoError(t, err)
 
-	assert.Equal(t, []string{"v1.0.0"}, config.Versions)
+	assert.Equal(t, []string{"v1.0.0"}, config.VersionNames())
 	assert.Equal(t, "Initial release", config.Description)
 }
 
@@ -32,14 +36,10 @@ func TestUpdateAppConfig_AppendNewVersion(t *testing.T) {
 	tmpDir := t.TempDir()
 	filePath := filepath.Join(tmpDir, "VERSION.json")
 
-	initialConfig := app.AppConfig{
-		Versions:    []string{"v1.0.0"},
-		Description: "Initial release",
-	}
-	data, _ := json.MarshalIndent(initialConfig, "", "  ")
-	_ = os.WriteFile(filePath, data, 0644)
+	// app.json files written by older versions list the versions as strings
+	_ = os.WriteFile(filePath, []byte(`{"versions": ["v1.0.0"], "description": "Initial release"}`), 0644)
 
-	err := app.UpdateAppConfig(filePath, "v1.1.0", "Initial release")
+	err := app.UpdateAppConfig(system.OSFileSystem{}, filePath, app.VersionEntry{Version: "v1.1.0"}, "Initial release")
 	assert.NoError(t, err)
 
 	newData, err := os.ReadFile(filePath)
@@ -48,7 +48,7 @@ func TestUpdateAppConfig_AppendNewVersion(t *testing.T) {
 	var config app.AppConfig
 	_ = json.Unmarshal(newData, &config)
 
-	assert.ElementsMatch(t, []string{"v1.0.0", "v1.1.0"}, config.Versions)
+	assert.ElementsMatch(t, []string{"v1.0.0", "v1.1.0"}, config.VersionNames())
 	assert.Equal(t, "Initial release", config.Description)
 }
 
@@ -56,21 +56,67 @@ func TestUpdateAppConfig_DuplicateVersion(t *testing.T) {
 	tmpDir := t.TempDir()
 	filePath := filepath.Join(tmpDir, "VERSION.json")
 
-	initialConfig := app.AppConfig{
-		Versions:    []string{"v1.0.0"},
-		Description: "Initial release",
-	}
-	data, _ := json.MarshalIndent(initialConfig, "", "  ")
-	_ = os.WriteFile(filePath, data, 0644)
+	// app.json files written by older versions list the versions as strings
+	_ = os.WriteFile(filePath, []byte(`{"versions": ["v1.0.0"], "description": "Initial release"}`), 0644)
 
-	err := app.UpdateAppConfig(filePath, "v1.0.0", "Initial release")
+	err := app.UpdateAppConfig(system.OSFileSystem{}, filePath, app.VersionEntry{Version: "v1.0.0"}, "Initial release")
 	assert.NoError(t, err)
 
 	newData, _ := os.ReadFile(filePath)
 	var config app.AppConfig
 	_ = json.Unmarshal(newData, &config)
 
-	assert.Equal(t, 1, len(config.Versions))
-	assert.Contains(t, config.Versions, "v1.0.0")
+	assert.Equal(t, 1, len(config.VersionNames()))
+	assert.Contains(t, config.VersionNames(), "v1.0.0")
 	assert.Equal(t, "Initial release", config.Description)
 }
+
+type fakeGitRunner struct{}
+
+func (fakeGitRunner) Execute(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	if args[0] == "rev-parse" {
+		return []byte("3ee42db9f1c2\n"), nil
+	}
+	return []byte("v1.2.0\n"), nil
+}
+
+func (fakeGitRunner) ExecuteWithInput(ctx context.Context, dir string, input []byte, name string, args ...string) ([]byte, error) {
+	return nil, nil
+}
+
+func TestNewVersionEntry(t *testing.T) {
+	projectDoc := doc.ProjectDoc{
+		PackageDocs: map[string][]doc.FileDoc{
+			"parser": {{FileName: "parser.go", Docs: []doc.DocItem{
+				&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "ParseDocForDir", Description: "Parse a directory", Type: doc.FUNCTION_TYPE}},
+				&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "DocParser", Type: doc.STRUCT_TYPE}},
+			}}},
+		},
+	}
+
+	entry := app.NewVersionEntry(context.Background(), "1.2.0", "", projectDoc, fakeGitRunner{})
+	assert.Equal(t, "1.2.0", entry.Version)
+	assert.Equal(t, "3ee42db9f1c2", entry.Commit)
+	assert.Equal(t, "v1.2.0", entry.Tag)
+	assert.Equal(t, doc.SCHEMA_VERSION, entry.SchemaVersion)
+	assert.Equal(t, 2, entry.Symbols)
+	assert.Equal(t, 1, entry.DocumentedSymbols)
+	_, err := time.Parse(time.RFC3339, entry.GeneratedAt)
+	assert.NoError(t, err)
+
+	entry = app.NewVersionEntry(context.Background(), "1.2.0", "", projectDoc, nil)
+	assert.Equal(t, "", entry.Commit)
+}
+
+func TestLoadAppConfig_Migration(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "app.json")
+	_ = os.WriteFile(filePath, []byte(`{"versions": ["1.0.0", {"version": "1.1.0", "commit": "3ee42db"}], "description": "ZenDoc"}`), 0644)
+
+	config, err := app.LoadAppConfig(system.OSFileSystem{}, filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, []app.VersionEntry{{Version: "1.0.0"}, {Version: "1.1.0", Commit: "3ee42db"}}, config.Versions)
+
+	_ = os.WriteFile(filePath, []byte(`{"versions": [1]}`), 0644)
+	_, err = app.LoadAppConfig(system.OSFileSystem{}, filePath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/dterbah/zendoc/internal"
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/system"
+)
+
+/*
+@description Struct to represent a version of the documentation in the app.json file, with the source revision it was generated from
+@author Dorian TERBAH
+@field Version string - The version of the documentation
+@field GeneratedAt string - The date of the generation, in RFC 3339 format
+@field Commit string - The SHA of the git commit the documentation was generated from
+@field Tag string - The git tag pointing to this commit, if any
+@field ZendocVersion string - The version of zendoc used for the generation
+@field SchemaVersion string - The version of the schema of the documentation file
+@field Symbols int - The number of documented symbols
+@field DocumentedSymbols int - The number of symbols having a description
+*/
+type VersionEntry struct {
+	Version           string `json:"version"`
+	GeneratedAt       string `json:"generatedAt,omitempty"`
+	Commit            string `json:"commit,omitempty"`
+	Tag               string `json:"tag,omitempty"`
+	ZendocVersion     string `json:"zendocVersion,omitempty"`
+	SchemaVersion     string `json:"schemaVersion,omitempty"`
+	Symbols           int    `json:"symbols"`
+	DocumentedSymbols int    `json:"documentedSymbols"`
+}
+
+// versionEntryJSON avoids the recursion of UnmarshalJSON
+type versionEntryJSON VersionEntry
+
+/*
+@description Decode a version entry. The entries of the app.json files written by older zendoc versions are plain strings, decoded as a version without metadata, so these files are migrated when they are saved again.
+@param data []byte - Either a JSON object or a JSON string
+@return error - An error if the data is neither a version string nor a version object
+@author Dorian TERBAH
+*/
+func (entry *VersionEntry) UnmarshalJSON(data []byte) error {
+	var version string
+	if err := json.Unmarshal(data, &version); err == nil {
+		*entry = VersionEntry{Version: version}
+		return nil
+	}
+
+	var value versionEntryJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*entry = VersionEntry(value)
+	return nil
+}
+
+/*
+@description Gather the metadata of a version being generated: the current date, git commit and tag, the versions of zendoc and of the schema, and the symbol counts
+@param ctx context.Context - The context of the git commands
+@param version string - The version of the documentation
+@param revision string - The git revision the documentation is generated from, HEAD when empty
+@param projectDoc doc.ProjectDoc - The generated documentation
+@param cmdRunner system.CommandRunner - The runner used to read the git revision, may be nil to skip it
+@return VersionEntry - The version and its metadata. The git fields are empty outside of a git repository.
+@author Dorian TERBAH
+*/
+func NewVersionEntry(ctx context.Context, version, revision string, projectDoc doc.ProjectDoc, cmdRunner system.CommandRunner) VersionEntry {
+	entry := VersionEntry{
+		Version:       version,
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		ZendocVersion: internal.ZENDOC_VERSION,
+		SchemaVersion: doc.SCHEMA_VERSION,
+	}
+
+	for _, symbol := range doc.Symbols(projectDoc) {
+		entry.Symbols++
+		if strings.TrimSpace(symbol.Item.GetBaseDoc().Description) != "" {
+			entry.DocumentedSymbols++
+		}
+	}
+
+	if cmdRunner == nil {
+		return entry
+	}
+	if revision == "" {
+		revision = "HEAD"
+	}
+	if output, err := cmdRunner.Execute(ctx, "", "git", "rev-parse", revision+"^{commit}"); err == nil {
+		entry.Commit = strings.TrimSpace(string(output))
+	}
+	if output, err := cmdRunner.Execute(ctx, "", "git", "describe", "--tags", "--exact-match", revision); err == nil {
+		entry.Tag = strings.TrimSpace(string(output))
+	}
+
+	return entry
+}
@@ -0,0 +1,360 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/dterbah/zendoc/internal/semver"
+	"github.com/dterbah/zendoc/internal/system"
+)
+
+const APP_CONFIG_FILE = "app.json"
+
+/*
+@description List the names of the files exported for a version next to the app.json file
+@param version string - The version of the documentation
+@return []string - The names of the documentation, search index and changelog files of the version
+@example VersionFileNames("1.0.0") => []string{"doc-1.0.0.json", "search-index-1.0.0.json", "changelog-1.0.0.json"}
+@author Dorian TERBAH
+*/
+func VersionFileNames(version string) []string {
+	return []string{
+		fmt.Sprintf("doc-%s.json", version),
+		fmt.Sprintf("search-index-%s.json", version),
+		fmt.Sprintf("changelog-%s.json", version),
+	}
+}
+
+/*
+@description Sort versions by semantic version, the lowest first. The versions that are not semantic are kept after them, in their current order.
+@param versions []string - The versions to sort, sorted in place
+@author Dorian TERBAH
+*/
+func SortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versionLess(versions[i], versions[j])
+	})
+}
+
+func versionLess(version, other string) bool {
+	first, firstErr := semver.Parse(version)
+	second, secondErr := semver.Parse(other)
+	switch {
+	case firstErr != nil:
+		return false
+	case secondErr != nil:
+		return true
+	}
+	return first.Compare(second) < 0
+}
+
+/*
+@description Add a version to the configuration, keeping the versions sorted. The entry of an already recorded version is replaced. A new stable version higher than the latest one becomes the latest version.
+@param entry VersionEntry - The version to add, with its metadata
+@author Dorian TERBAH
+*/
+func (config *AppConfig) AddVersion(entry VersionEntry) {
+	if index := config.indexOf(entry.Version); index >= 0 {
+		config.Versions[index] = entry
+	} else {
+		config.Versions = append(config.Versions, entry)
+	}
+	config.sortVersions()
+
+	switch {
+	case config.Latest == "":
+		config.Latest = highestStable(config.VersionNames())
+	case isNewerStable(entry.Version, config.Latest):
+		config.Latest = entry.Version
+	}
+}
+
+/*
+@description Remove a version from the configuration. When the latest version is removed, the highest remaining stable version becomes the latest one.
+@param version string - The version to remove
+@return error - An error if the version does not exist
+@author Dorian TERBAH
+*/
+func (config *AppConfig) RemoveVersion(version string) error {
+	index := config.indexOf(version)
+	if index < 0 {
+		return fmt.Errorf("unknown version %s", version)
+	}
+	config.Versions = slices.Delete(config.Versions, index, index+1)
+
+	if config.Next == version {
+		config.Next = ""
+	}
+	if config.Latest == version {
+		config.Latest = highestStable(config.VersionNames())
+	}
+	return nil
+}
+
+/*
+@description Rename a version of the configuration, keeping its latest and next designations
+@param oldVersion string - The current name of the version
+@param newVersion string - The new name of the version
+@return error - An error if the old version does not exist or the new one already exists
+@author Dorian TERBAH
+*/
+func (config *AppConfig) RenameVersion(oldVersion, newVersion string) error {
+	index := config.indexOf(oldVersion)
+	if index < 0 {
+		return fmt.Errorf("unknown version %s", oldVersion)
+	}
+	if config.indexOf(newVersion) >= 0 {
+		return fmt.Errorf("the version %s already exists", newVersion)
+	}
+
+	config.Versions[index].Version = newVersion
+	config.sortVersions()
+
+	if config.Latest == oldVersion {
+		config.Latest = newVersion
+	}
+	if config.Next == oldVersion {
+		config.Next = newVersion
+	}
+	return nil
+}
+
+/*
+@description Designate the latest version, shown by default
+@param version string - An existing version
+@return error - An error if the version does not exist
+@author Dorian TERBAH
+*/
+func (config *AppConfig) SetLatest(version string) error {
+	if config.indexOf(version) < 0 {
+		return fmt.Errorf("unknown version %s", version)
+	}
+	config.Latest = version
+	return nil
+}
+
+/*
+@description Designate the version of the next/dev channel
+@param version string - An existing version, or an empty string to remove the channel
+@return error - An error if the version does not exist
+@author Dorian TERBAH
+*/
+func (config *AppConfig) SetNext(version string) error {
+	if version != "" && config.indexOf(version) < 0 {
+		return fmt.Errorf("unknown version %s", version)
+	}
+	config.Next = version
+	return nil
+}
+
+/*
+@description Remove the oldest semantic versions, keeping the given number of them. The latest and next versions are always kept and count in this number, then the highest versions fill the remaining places. The versions that are not semantic (e.g. a branch name) cannot be ordered by age, so they are never removed and are not counted.
+@param keep int - The number of semantic versions to keep, latest and next included
+@return []string - The removed versions, the lowest first
+@example config.Prune(2) with the versions 1.0.0, 1.1.0, 1.2.0 and main, and the latest version 1.0.0 => ["1.1.0"]
+@author Dorian TERBAH
+*/
+func (config *AppConfig) Prune(keep int) []string {
+	keptVersions := map[string]bool{}
+	for _, version := range []string{config.Latest, config.Next} {
+		if _, err := semver.Parse(version); err == nil && config.indexOf(version) >= 0 {
+			keptVersions[version] = true
+		}
+	}
+
+	// the versions are sorted the lowest first, with the versions that are not semantic at the end
+	for i := len(config.Versions) - 1; i >= 0 && len(keptVersions) < keep; i-- {
+		if _, err := semver.Parse(config.Versions[i].Version); err == nil {
+			keptVersions[config.Versions[i].Version] = true
+		}
+	}
+
+	removed := []string{}
+	kept := []VersionEntry{}
+	for _, entry := range config.Versions {
+		if _, err := semver.Parse(entry.Version); err == nil && !keptVersions[entry.Version] {
+			removed = append(removed, entry.Version)
+			continue
+		}
+		kept = append(kept, entry)
+	}
+
+	config.Versions = kept
+	return removed
+}
+
+/*
+@description Remove a version from the app.json file of a directory, with its exported files
+@param fileSystem system.FileSystem - The file system holding the documentation
+@param assetsDir string - The directory holding the app.json file and the files of the versions
+@param version string - The version to remove
+@return error - An error if the version does not exist or the files cannot be updated
+@author Dorian TERBAH
+*/
+func RemoveVersion(fileSystem system.FileSystem, assetsDir, version string) error {
+	return updateVersions(fileSystem, assetsDir, func(config *AppConfig, transaction *system.Transaction) error {
+		if err := config.RemoveVersion(version); err != nil {
+			return err
+		}
+		removeVersionFiles(transaction, assetsDir, version)
+		return nil
+	})
+}
+
+/*
+@description Rename a version in the app.json file of a directory, with its exported files
+@param fileSystem system.FileSystem - The file system holding the documentation
+@param assetsDir string - The directory holding the app.json file and the files of the versions
+@param oldVersion string - The current name of the version
+@param newVersion string - The new name of the version
+@return error - An error if the version cannot be renamed or the files cannot be updated
+@author Dorian TERBAH
+*/
+func RenameVersion(fileSystem system.FileSystem, assetsDir, oldVersion, newVersion string) error {
+	return updateVersions(fileSystem, assetsDir, func(config *AppConfig, transaction *system.Transaction) error {
+		if err := config.RenameVersion(oldVersion, newVersion); err != nil {
+			return err
+		}
+
+		newNames := VersionFileNames(newVersion)
+		for i, name := range VersionFileNames(oldVersion) {
+			err := transaction.Rename(filepath.Join(assetsDir, name), filepath.Join(assetsDir, newNames[i]))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("error when renaming %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+}
+
+/*
+@description Designate the latest version in the app.json file of a directory
+@param fileSystem system.FileSystem - The file system holding the documentation
+@param assetsDir string - The directory holding the app.json file
+@param version string - An existing version
+@return error - An error if the version does not exist or the file cannot be updated
+@author Dorian TERBAH
+*/
+func SetLatestVersion(fileSystem system.FileSystem, assetsDir, version string) error {
+	return updateVersions(fileSystem, assetsDir, func(config *AppConfig, transaction *system.Transaction) error {
+		return config.SetLatest(version)
+	})
+}
+
+/*
+@description Designate the version of the next/dev channel in the app.json file of a directory
+@param fileSystem system.FileSystem - The file system holding the documentation
+@param assetsDir string - The directory holding the app.json file
+@param version string - An existing version, or an empty string to remove the channel
+@return error - An error if the version does not exist or the file cannot be updated
+@author Dorian TERBAH
+*/
+func SetNextVersion(fileSystem system.FileSystem, assetsDir, version string) error {
+	return updateVersions(fileSystem, assetsDir, func(config *AppConfig, transaction *system.Transaction) error {
+		return config.SetNext(version)
+	})
+}
+
+/*
+@description Remove the oldest versions from the app.json file of a directory, with their exported files. See AppConfig.Prune for the versions kept.
+@param fileSystem system.FileSystem - The file system holding the documentation
+@param assetsDir string - The directory holding the app.json file and the files of the versions
+@param keep int - The number of semantic versions to keep, latest and next included
+@return ([]string, error) - The removed versions and an error if the files cannot be updated
+@author Dorian TERBAH
+*/
+func PruneVersions(fileSystem system.FileSystem, assetsDir string, keep int) ([]string, error) {
+	if keep < 1 {
+		return nil, fmt.Errorf("at least one version must be kept")
+	}
+
+	removed := []string{}
+	err := updateVersions(fileSystem, assetsDir, func(config *AppConfig, transaction *system.Transaction) error {
+		removed = config.Prune(keep)
+		for _, version := range removed {
+			removeVersionFiles(transaction, assetsDir, version)
+		}
+		return nil
+	})
+	return removed, err
+}
+
+func (config *AppConfig) sortVersions() {
+	sort.SliceStable(config.Versions, func(i, j int) bool {
+		return versionLess(config.Versions[i].Version, config.Versions[j].Version)
+	})
+}
+
+func (config AppConfig) indexOf(version string) int {
+	return slices.IndexFunc(config.Versions, func(entry VersionEntry) bool {
+		return entry.Version == version
+	})
+}
+
+// updateVersions loads the app.json file of a directory and applies the update, which stages the changes of the version files. They are committed with the new app.json file, so that app.json never lists a version without its files.
+func updateVersions(fileSystem system.FileSystem, assetsDir string, update func(config *AppConfig, transaction *system.Transaction) error) error {
+	appPath := filepath.Join(assetsDir, APP_CONFIG_FILE)
+	config, err := LoadAppConfig(fileSystem, appPath)
+	if err != nil {
+		return err
+	}
+
+	transaction := system.NewTransaction(fileSystem)
+	defer transaction.Rollback()
+
+	if err := update(config, transaction); err != nil {
+		return err
+	}
+
+	content, err := config.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := transaction.WriteFile(appPath, content, 0644); err != nil {
+		return fmt.Errorf("error when saving %s: %w", appPath, err)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return fmt.Errorf("error when updating the versions of your documentation: %w", err)
+	}
+	return nil
+}
+
+func removeVersionFiles(transaction *system.Transaction, assetsDir, version string) {
+	for _, name := range VersionFileNames(version) {
+		transaction.Remove(filepath.Join(assetsDir, name))
+	}
+}
+
+// isNewerStable checks if a version is a release higher than the other one
+func isNewerStable(version, other string) bool {
+	parsed, err := semver.Parse(version)
+	if err != nil || parsed.Prerelease != "" {
+		return false
+	}
+
+	otherParsed, err := semver.Parse(other)
+	if err != nil {
+		return true
+	}
+	return parsed.Compare(otherParsed) > 0
+}
+
+// highestStable returns the highest release, or the last version when there is none
+func highestStable(versions []string) string {
+	latest := ""
+	for _, version := range versions {
+		if isNewerStable(version, latest) {
+			latest = version
+		}
+	}
+
+	if latest == "" && len(versions) > 0 {
+		return versions[len(versions)-1]
+	}
+	return latest
+}
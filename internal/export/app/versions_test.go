@@ -0,0 +1,155 @@
+package app_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/export/app"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func entries(versions ...string) []app.VersionEntry {
+	entries := []app.VersionEntry{}
+	for _, version := range versions {
+		entries = append(entries, app.VersionEntry{Version: version})
+	}
+	return entries
+}
+
+func TestSortVersions(t *testing.T) {
+	versions := []string{"1.10.0", "latest", "v1.2.0", "1.2.0-rc.1", "0.9", "dev"}
+	app.SortVersions(versions)
+
+	assert.Equal(t, []string{"0.9", "1.2.0-rc.1", "v1.2.0", "1.10.0", "latest", "dev"}, versions)
+}
+
+func TestAppConfig_AddVersion(t *testing.T) {
+	config := app.AppConfig{Versions: entries("1.2.0", "1.0.0")}
+
+	config.AddVersion(app.VersionEntry{Version: "1.1.0"})
+	assert.Equal(t, []string{"1.0.0", "1.1.0", "1.2.0"}, config.VersionNames())
+	// without designated latest version, the highest release is used
+	assert.Equal(t, "1.2.0", config.Latest)
+
+	config.AddVersion(app.VersionEntry{Version: "2.0.0-rc.1"})
+	assert.Equal(t, "1.2.0", config.Latest)
+
+	config.AddVersion(app.VersionEntry{Version: "2.0.0"})
+	assert.Equal(t, "2.0.0", config.Latest)
+	assert.Equal(t, []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0-rc.1", "2.0.0"}, config.VersionNames())
+}
+
+func TestAppConfig_Designations(t *testing.T) {
+	config := app.AppConfig{Versions: entries("1.0.0", "1.1.0", "2.0.0-rc.1"), Latest: "1.1.0"}
+
+	assert.NoError(t, config.SetNext("2.0.0-rc.1"))
+	assert.ErrorContains(t, config.SetLatest("3.0.0"), "unknown version 3.0.0")
+
+	assert.NoError(t, config.RenameVersion("2.0.0-rc.1", "2.0.0-rc.2"))
+	assert.Equal(t, "2.0.0-rc.2", config.Next)
+	assert.ErrorContains(t, config.RenameVersion("1.0.0", "1.1.0"), "already exists")
+
+	assert.NoError(t, config.RemoveVersion("1.1.0"))
+	assert.Equal(t, "1.0.0", config.Latest)
+	assert.ErrorContains(t, config.RemoveVersion("1.1.0"), "unknown version")
+
+	assert.NoError(t, config.SetNext(""))
+	assert.Equal(t, "", config.Next)
+}
+
+func TestAppConfig_Prune(t *testing.T) {
+	config := app.AppConfig{Versions: entries("1.0.0", "1.1.0", "1.2.0", "1.3.0", "2.0.0-rc.1", "main"), Latest: "1.1.0", Next: "2.0.0-rc.1"}
+
+	assert.Equal(t, []string{"1.0.0", "1.2.0"}, config.Prune(3))
+	assert.Equal(t, []string{"1.1.0", "1.3.0", "2.0.0-rc.1", "main"}, config.VersionNames())
+
+	assert.Equal(t, []string{"1.3.0"}, config.Prune(2))
+	assert.Equal(t, []string{"1.1.0", "2.0.0-rc.1", "main"}, config.VersionNames())
+
+	// the latest and next versions are kept even when they do not fit
+	assert.Empty(t, config.Prune(1))
+	assert.Equal(t, []string{"1.1.0", "2.0.0-rc.1", "main"}, config.VersionNames())
+}
+
+func TestVersionFiles(t *testing.T) {
+	assetsDir := t.TempDir()
+	assert.NoError(t, app.UpdateAppConfig(system.OSFileSystem{}, filepath.Join(assetsDir, app.APP_CONFIG_FILE), app.VersionEntry{Version: "1.0.0"}, "ZenDoc"))
+	assert.NoError(t, app.UpdateAppConfig(system.OSFileSystem{}, filepath.Join(assetsDir, app.APP_CONFIG_FILE), app.VersionEntry{Version: "1.1.0"}, "ZenDoc"))
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		for _, name := range app.VersionFileNames(version) {
+			assert.NoError(t, os.WriteFile(filepath.Join(assetsDir, name), []byte("{}"), 0644))
+		}
+	}
+
+	assert.NoError(t, app.RenameVersion(system.OSFileSystem{}, assetsDir, "1.1.0", "1.1.1"))
+	assert.FileExists(t, filepath.Join(assetsDir, "doc-1.1.1.json"))
+	assert.FileExists(t, filepath.Join(assetsDir, "search-index-1.1.1.json"))
+	assert.NoFileExists(t, filepath.Join(assetsDir, "doc-1.1.0.json"))
+
+	removed, err := app.PruneVersions(system.OSFileSystem{}, assetsDir, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.0.0"}, removed)
+	assert.NoFileExists(t, filepath.Join(assetsDir, "doc-1.0.0.json"))
+
+	assert.NoError(t, app.SetLatestVersion(system.OSFileSystem{}, assetsDir, "1.1.1"))
+	config, err := app.LoadAppConfig(system.OSFileSystem{}, filepath.Join(assetsDir, app.APP_CONFIG_FILE))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.1.1"}, config.VersionNames())
+	assert.Equal(t, "1.1.1", config.Latest)
+
+	assert.NoError(t, app.RemoveVersion(system.OSFileSystem{}, assetsDir, "1.1.1"))
+	assert.NoFileExists(t, filepath.Join(assetsDir, "changelog-1.1.1.json"))
+	assert.ErrorContains(t, app.SetNextVersion(system.OSFileSystem{}, assetsDir, "1.1.1"), "unknown version")
+
+	_, err = app.PruneVersions(system.OSFileSystem{}, assetsDir, 0)
+	assert.Error(t, err)
+}
+
+// failingAppFileSystem fails to move the new app.json file into place
+type failingAppFileSystem struct {
+	*system.MemoryFileSystem
+}
+
+func (f failingAppFileSystem) Rename(oldPath, newPath string) error {
+	if newPath == filepath.Join("assets", app.APP_CONFIG_FILE) && strings.HasSuffix(oldPath, system.TRANSACTION_STAGED_SUFFIX) {
+		return errors.New("disk full")
+	}
+	return f.MemoryFileSystem.Rename(oldPath, newPath)
+}
+
+func TestVersionFiles_CommitFailure(t *testing.T) {
+	memoryFs := system.NewMemoryFileSystem()
+	appPath := filepath.Join("assets", app.APP_CONFIG_FILE)
+	assert.NoError(t, memoryFs.MkdirAll("assets", 0755))
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		assert.NoError(t, app.UpdateAppConfig(memoryFs, appPath, app.VersionEntry{Version: version}, "ZenDoc"))
+		for _, name := range app.VersionFileNames(version) {
+			assert.NoError(t, memoryFs.WriteFile(filepath.Join("assets", name), []byte("{}"), 0644))
+		}
+	}
+	fileSystem := failingAppFileSystem{MemoryFileSystem: memoryFs}
+
+	// the files of the versions are only changed with app.json
+	assert.ErrorContains(t, app.RemoveVersion(fileSystem, "assets", "1.0.0"), "disk full")
+	assert.ErrorContains(t, app.RenameVersion(fileSystem, "assets", "1.1.0", "1.1.1"), "disk full")
+	_, err := app.PruneVersions(fileSystem, "assets", 1)
+	assert.ErrorContains(t, err, "disk full")
+
+	config, err := app.LoadAppConfig(memoryFs, appPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.0.0", "1.1.0"}, config.VersionNames())
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		for _, name := range app.VersionFileNames(version) {
+			assert.True(t, memoryFs.FileExists(filepath.Join("assets", name)))
+		}
+	}
+	assert.False(t, memoryFs.FileExists(filepath.Join("assets", "doc-1.1.1.json")))
+	entries, err := fs.ReadDir(memoryFs, "assets")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 7)
+}
@@ -1,6 +1,12 @@
 package export
 
-import "github.com/dterbah/zendoc/internal/doc"
+import (
+	"context"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/helper"
+	"github.com/dterbah/zendoc/internal/parser"
+)
 
 /*
 @description Export a project doc in a specific format
@@ -9,9 +15,52 @@ import "github.com/dterbah/zendoc/internal/doc"
 type DocExporter interface {
 	/*
 		@description Export the documentation
+		@param ctx context.Context - The context of the export
 		@param projectDoc doc.ProjectDoc - The associated doc to export
 		@author Dorian TERBAH
 		@return error - If there is any problem during the export
 	*/
-	Export(projectDoc doc.ProjectDoc) error
+	Export(ctx context.Context, projectDoc doc.ProjectDoc) error
+}
+
+/*
+@description Source of a documentation streamed file by file: it gives the documentation of each file to the visitor, typically as soon as the file is parsed
+@author Dorian TERBAH
+*/
+type DocStream func(visit parser.FileDocVisitor) error
+
+/*
+@description Exporter writing the documentation file by file, as it is parsed, so that the whole project is never held in memory
+@author Dorian TERBAH
+*/
+type StreamExporter interface {
+	DocExporter
+
+	/*
+		@description Export the documentation of the files given by the stream
+		@param ctx context.Context - The context of the export
+		@param stream DocStream - The source of the documentation
+		@author Dorian TERBAH
+		@return error - If there is any problem during the parsing or the export
+	*/
+	ExportStream(ctx context.Context, stream DocStream) error
+}
+
+/*
+@description Stream a documentation already in memory, package by package in alphabetical order, to export it with a StreamExporter
+@param projectDoc doc.ProjectDoc - The documentation to stream
+@return DocStream - The stream of the files of the documentation
+@author Dorian TERBAH
+*/
+func ProjectDocStream(projectDoc doc.ProjectDoc) DocStream {
+	return func(visit parser.FileDocVisitor) error {
+		for _, pckName := range helper.SortedPackages(projectDoc) {
+			for _, fileDoc := range projectDoc.PackageDocs[pckName] {
+				if err := visit(pckName, fileDoc); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
 }
@@ -1,11 +0,0 @@
-package helper
-
-import "os"
-
-func IsFileExist(path string) bool {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return false
-	}
-
-	return true
-}
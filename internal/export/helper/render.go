@@ -0,0 +1,215 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dterbah/zendoc/internal/doc"
+)
+
+/*
+@description Compute the anchor of a heading, following the slug rules of GitHub, GitLab and Gitea (lowercase, punctuation removed, spaces replaced by hyphens)
+@param heading string - The text of the heading
+@return string - The anchor, without the leading '#'
+@example Anchor("DocParser.ParseDocForDir") => "docparserparsedocfordir"
+@author Dorian TERBAH
+*/
+func Anchor(heading string) string {
+	var builder strings.Builder
+
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			builder.WriteRune(r)
+		case r == ' ':
+			builder.WriteRune('-')
+		}
+	}
+
+	return builder.String()
+}
+
+/*
+@description Compute the qualified name of a function, prefixed by its struct for methods
+@param fd doc.FuncDoc - The documented function
+@return string - The qualified name of the function
+@example QualifiedName(fd) => "DocParser.ParseDocForDir"
+@author Dorian TERBAH
+*/
+func QualifiedName(fd doc.FuncDoc) string {
+	if fd.Struct != "" {
+		return fd.Struct + "." + fd.Name
+	}
+	return fd.Name
+}
+
+/*
+@description Render the Go signature of a function from its documentation tags
+@param fd doc.FuncDoc - The documented function
+@return string - The signature of the function
+@example FuncSignature(fd) => "func (DocParser) ParseDocForDir(dirPath string, currentPath string) (*doc.ProjectDoc, error)"
+@author Dorian TERBAH
+*/
+func FuncSignature(fd doc.FuncDoc) string {
+	var builder strings.Builder
+
+	builder.WriteString("func ")
+	if fd.Struct != "" {
+		fmt.Fprintf(&builder, "(%s) ", fd.Struct)
+	}
+	builder.WriteString(methodSignature(fd))
+
+	return builder.String()
+}
+
+// methodSignature renders a function signature without the func keyword and the receiver
+func methodSignature(fd doc.FuncDoc) string {
+	params := []string{}
+	for _, param := range fd.Params {
+		params = append(params, strings.TrimSpace(param.Name+" "+param.Type))
+	}
+
+	signature := fmt.Sprintf("%s(%s)", fd.Name, strings.Join(params, ", "))
+	if fd.Return != nil && fd.Return.Type != "" {
+		signature += " " + returnType(fd.Return.Type)
+	}
+	return signature
+}
+
+// returnType wraps multiple return values in parentheses when the tag omits them
+func returnType(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, ",") && !strings.HasPrefix(value, "(") {
+		return "(" + value + ")"
+	}
+	return value
+}
+
+/*
+@description Render the Go declaration of a struct from its documented fields
+@param sd doc.StructDoc - The documented struct
+@return string - The declaration of the struct
+@example StructSignature(sd) => "type DocParser struct {\n\tFileValidators []DocParserFileValidator\n}"
+@author Dorian TERBAH
+*/
+func StructSignature(sd doc.StructDoc) string {
+	if len(sd.Fields) == 0 {
+		return fmt.Sprintf("type %s struct{}", sd.Name)
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "type %s struct {\n", sd.Name)
+	for _, field := range sd.Fields {
+		fmt.Fprintf(&builder, "\t%s %s\n", field.Name, field.Type)
+	}
+	builder.WriteString("}")
+
+	return builder.String()
+}
+
+/*
+@description Render the Go declaration of an interface from its documented methods
+@param id doc.InterfaceDoc - The documented interface
+@return string - The declaration of the interface
+@example InterfaceSignature(id) => "type CommandRunner interface {\n\tExecute(dir string) ([]byte, error)\n}"
+@author Dorian TERBAH
+*/
+func InterfaceSignature(id doc.InterfaceDoc) string {
+	if len(id.Methods) == 0 {
+		return fmt.Sprintf("type %s interface{}", id.Name)
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "type %s interface {\n", id.Name)
+	for _, method := range id.Methods {
+		fmt.Fprintf(&builder, "\t%s\n", methodSignature(method))
+	}
+	builder.WriteString("}")
+
+	return builder.String()
+}
+
+/*
+@description Render the Go signature of any documented item, preferring the declaration read from the source code over the one rebuilt from the documentation tags
+@param item doc.DocItem - The documented item
+@return string - The signature of the item, or an empty string for unknown kinds
+@author Dorian TERBAH
+*/
+func Signature(item doc.DocItem) string {
+	if signature := item.GetBaseDoc().Signature; signature != "" {
+		return signature
+	}
+
+	switch d := item.(type) {
+	case *doc.FuncDoc:
+		return FuncSignature(*d)
+	case *doc.StructDoc:
+		return StructSignature(*d)
+	case *doc.InterfaceDoc:
+		return InterfaceSignature(*d)
+	}
+	return ""
+}
+
+/*
+@description Retrieve the package names of a project in alphabetical order
+@param projectDoc doc.ProjectDoc - The project documentation
+@return []string - The sorted package names
+@author Dorian TERBAH
+*/
+func SortedPackages(projectDoc doc.ProjectDoc) []string {
+	packages := make([]string, 0, len(projectDoc.PackageDocs))
+	for pckName := range projectDoc.PackageDocs {
+		packages = append(packages, pckName)
+	}
+	sort.Strings(packages)
+
+	return packages
+}
+
+/*
+@description Struct to represent a documented item with the file declaring it
+@author Dorian TERBAH
+@field Item doc.DocItem - The documented item
+@field File doc.FileDoc - The file declaring the item
+*/
+type PackageItem struct {
+	Item doc.DocItem
+	File doc.FileDoc
+}
+
+/*
+@description Retrieve the documented items of a package, sorted by name (methods are sorted by their qualified name)
+@param files []doc.FileDoc - The files of the package
+@return []PackageItem - The sorted items with their file
+@author Dorian TERBAH
+*/
+func SortedItems(files []doc.FileDoc) []PackageItem {
+	items := []PackageItem{}
+	for _, file := range files {
+		for _, item := range file.Docs {
+			items = append(items, PackageItem{Item: item, File: file})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return ItemName(items[i].Item) < ItemName(items[j].Item)
+	})
+
+	return items
+}
+
+/*
+@description Retrieve the display name of a documented item, qualified by its struct for methods
+@param item doc.DocItem - The documented item
+@return string - The name of the item
+@author Dorian TERBAH
+*/
+func ItemName(item doc.DocItem) string {
+	if fd, ok := item.(*doc.FuncDoc); ok {
+		return QualifiedName(*fd)
+	}
+	return item.GetBaseDoc().Name
+}
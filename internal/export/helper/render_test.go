@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnchor(t *testing.T) {
+	assert.Equal(t, "docparserparsedocfordir", Anchor("DocParser.ParseDocForDir"))
+	assert.Equal(t, "package-parser", Anchor("Package parser"))
+	assert.Equal(t, "is_test-file", Anchor(" is_test-File! "))
+}
+
+func TestFuncSignature(t *testing.T) {
+	fd := doc.FuncDoc{
+		BaseDoc: doc.BaseDoc{Name: "ParseDocForDir"},
+		Params: []doc.Param{
+			{Name: "dirPath", Type: "string"},
+			{Name: "currentPath", Type: "string"},
+		},
+		Return: &doc.Return{Type: "*doc.ProjectDoc, error"},
+		Struct: "DocParser",
+	}
+
+	assert.Equal(t, "func (DocParser) ParseDocForDir(dirPath string, currentPath string) (*doc.ProjectDoc, error)", FuncSignature(fd))
+	assert.Equal(t, "DocParser.ParseDocForDir", QualifiedName(fd))
+
+	fd.Struct = ""
+	fd.Return = &doc.Return{Type: "(string, error)"}
+	assert.Equal(t, "func ParseDocForDir(dirPath string, currentPath string) (string, error)", FuncSignature(fd))
+}
+
+func TestTypeSignatures(t *testing.T) {
+	sd := doc.StructDoc{
+		BaseDoc: doc.BaseDoc{Name: "Return"},
+		Fields:  []doc.StructField{{Name: "Type", Type: "string"}},
+	}
+	assert.Equal(t, "type Return struct {\n\tType string\n}", StructSignature(sd))
+
+	id := doc.InterfaceDoc{
+		BaseDoc: doc.BaseDoc{Name: "DocExporter"},
+		Methods: []doc.FuncDoc{
+			{
+				BaseDoc: doc.BaseDoc{Name: "Export"},
+				Params:  []doc.Param{{Name: "projectDoc", Type: "doc.ProjectDoc"}},
+				Return:  &doc.Return{Type: "error"},
+			},
+		},
+	}
+	assert.Equal(t, "type DocExporter interface {\n\tExport(projectDoc doc.ProjectDoc) error\n}", InterfaceSignature(id))
+	assert.Equal(t, "type Empty interface{}", InterfaceSignature(doc.InterfaceDoc{BaseDoc: doc.BaseDoc{Name: "Empty"}}))
+}
+
+func TestSignature(t *testing.T) {
+	sd := &doc.StructDoc{
+		BaseDoc: doc.BaseDoc{Name: "Return"},
+		Fields:  []doc.StructField{{Name: "Type", Type: "string"}},
+	}
+	assert.Equal(t, "type Return struct {\n\tType string\n}", Signature(sd))
+
+	sd.Signature = "type Return struct {\n\tType\tstring\n\tDescription\tstring\n}"
+	assert.Equal(t, sd.Signature, Signature(sd))
+}
+
+func TestSortedItems(t *testing.T) {
+	files := []doc.FileDoc{
+		{Path: "b.go", Docs: []doc.DocItem{&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "Zeta"}}}},
+		{Path: "a.go", Docs: []doc.DocItem{
+			&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "Alpha"}},
+			&doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "Run"}, Struct: "Alpha"},
+		}},
+	}
+
+	items := SortedItems(files)
+	assert.Len(t, items, 3)
+	assert.Equal(t, "Alpha", ItemName(items[0].Item))
+	assert.Equal(t, "Alpha.Run", ItemName(items[1].Item))
+	assert.Equal(t, "Zeta", ItemName(items[2].Item))
+	assert.Equal(t, "b.go", items[2].File.Path)
+}
@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/yuin/goldmark"
+)
+
+/*
+@description Convert a Markdown text to HTML
+@param text string - The Markdown text
+@return template.HTML - The rendered HTML, marked as safe for html/template. Raw HTML in the text is not rendered.
+@example MarkdownToHTML("**bold**") => "<p><strong>bold</strong></p>\n"
+@author Dorian TERBAH
+*/
+func MarkdownToHTML(text string) template.HTML {
+	var buf bytes.Buffer
+	if err := goldmark.Convert([]byte(text), &buf); err != nil {
+		return template.HTML(template.HTMLEscapeString(text))
+	}
+
+	return template.HTML(buf.String())
+}
+
+/*
+@description Sort documented items by name, methods being sorted by their qualified name
+@param items []doc.DocItem - The items to sort
+@return []doc.DocItem - A sorted copy of the items
+@author Dorian TERBAH
+*/
+func SortByName(items []doc.DocItem) []doc.DocItem {
+	sorted := append([]doc.DocItem{}, items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return ItemName(sorted[i]) < ItemName(sorted[j])
+	})
+
+	return sorted
+}
+
+/*
+@description Build the helper functions available in the documentation templates, usable with both text/template and html/template
+@return map[string]any - The function map
+@author Dorian TERBAH
+*/
+func TemplateFuncs() map[string]any {
+	return map[string]any{
+		"anchor":        Anchor,
+		"signature":     Signature,
+		"funcSignature": FuncSignature,
+		"itemName":      ItemName,
+		"markdown":      MarkdownToHTML,
+		"packages":      SortedPackages,
+		"items":         SortedItems,
+		"sortByName":    SortByName,
+		"lower":         strings.ToLower,
+		"upper":         strings.ToUpper,
+		"join":          strings.Join,
+		"replace":       strings.ReplaceAll,
+		"trim":          strings.TrimSpace,
+		"base": func(item doc.DocItem) *doc.BaseDoc {
+			return item.GetBaseDoc()
+		},
+		"asFunc": func(item doc.DocItem) *doc.FuncDoc {
+			fd, _ := item.(*doc.FuncDoc)
+			return fd
+		},
+		"asStruct": func(item doc.DocItem) *doc.StructDoc {
+			sd, _ := item.(*doc.StructDoc)
+			return sd
+		},
+		"asInterface": func(item doc.DocItem) *doc.InterfaceDoc {
+			id, _ := item.(*doc.InterfaceDoc)
+			return id
+		},
+	}
+}
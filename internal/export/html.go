@@ -0,0 +1,130 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/app"
+	"github.com/dterbah/zendoc/internal/export/site"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
+)
+
+const HTML_DIR = "html"
+const HTML_APP_FILE = "app.json"
+
+/*
+@description Struct that implements the DocExporter interface and exports the documentation as a static HTML site. Everything is rendered by the zendoc binary, without git or npm. Each version is written in its own folder, next to an app.json file listing the versions.
+@author Dorian TERBAH
+@field OutputDir string - The directory of the site
+@field AppName string - The name of the project, used as title of the site
+@field Description string - The description of the project
+@field Version string - The version of the documentation to export
+@field FileSystem system.FileSystem - The file system used to write the site
+@field CmdRunner system.CommandRunner - The runner used to record the git revision of the version in app.json, may be nil
+@field Revision string - The git revision the documentation is generated from, HEAD when empty
+*/
+type HTMLExporter struct {
+	DocExporter
+	OutputDir   string
+	AppName     string
+	Description string
+	Version     string
+	FileSystem  system.FileSystem
+	CmdRunner   system.CommandRunner
+	Revision    string
+}
+
+/*
+@description Export the project documentation as a static HTML site
+@param ctx context.Context - The context of the export
+@param projectDoc doc.ProjectDoc - The documentation to export
+@return error - An error if the rendering or the writing of the site fails
+@example HTMLExporter{OutputDir: "doc/html", Version: "1.0", FileSystem: system.OSFileSystem{}}.Export(ctx, projectDoc)
+@author Dorian TERBAH
+*/
+func (htmlExport HTMLExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	files, err := site.Build(projectDoc, site.Options{
+		Title:       htmlExport.AppName,
+		Description: htmlExport.Description,
+		Version:     htmlExport.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("error when rendering the HTML documentation: %w", err)
+	}
+
+	entry := app.NewVersionEntry(ctx, htmlExport.Version, htmlExport.Revision, projectDoc, htmlExport.CmdRunner)
+
+	// nothing is written once the export is cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// the site of a version only appears in app.json and in the version menu once all its pages are written
+	transaction := system.NewTransaction(htmlExport.FileSystem)
+	defer transaction.Rollback()
+
+	versionDir := filepath.Join(htmlExport.OutputDir, htmlExport.Version)
+	if err := htmlExport.writeFiles(transaction, versionDir, files); err != nil {
+		return err
+	}
+
+	appPath := filepath.Join(htmlExport.OutputDir, HTML_APP_FILE)
+	appConfig, err := app.AppConfigWithVersion(htmlExport.FileSystem, appPath, entry, htmlExport.Description)
+	if err != nil {
+		return fmt.Errorf("error when updating the versions of your documentation: %w", err)
+	}
+
+	versions, err := site.BuildVersions(appConfig.VersionNames(), htmlExport.Version)
+	if err != nil {
+		return err
+	}
+
+	rootFiles := map[string][]byte{
+		site.VERSIONS_FILE: versions,
+		site.INDEX_PAGE:    site.BuildRedirect(htmlExport.Version),
+	}
+	if err := htmlExport.writeFiles(transaction, htmlExport.OutputDir, rootFiles); err != nil {
+		return err
+	}
+
+	content, err := appConfig.Marshal()
+	if err != nil {
+		return fmt.Errorf("error when updating the versions of your documentation: %w", err)
+	}
+	if err := transaction.WriteFile(appPath, content, 0644); err != nil {
+		return fmt.Errorf("error when updating the versions of your documentation: %w", err)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return fmt.Errorf("error when saving the HTML documentation: %w", err)
+	}
+
+	color.Green("HTML documentation v%s saved in %s!", htmlExport.Version, htmlExport.OutputDir)
+	return nil
+}
+
+// writeFiles stages the files of the site in a directory, creating the intermediate folders
+func (htmlExport HTMLExporter) writeFiles(transaction *system.Transaction, dir string, files map[string][]byte) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := transaction.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return fmt.Errorf("error when creating the folder of %s: %w", target, err)
+		}
+		if err := transaction.WriteFile(target, files[path], 0644); err != nil {
+			return fmt.Errorf("error when saving %s: %w", target, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/export/app"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLExporter_Export(t *testing.T) {
+	outputDir := t.TempDir()
+	exporter := HTMLExporter{
+		OutputDir:  outputDir,
+		AppName:    "zendoc",
+		Version:    "1.0",
+		FileSystem: system.OSFileSystem{},
+	}
+
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	exporter.Version = "1.1"
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	for _, version := range []string{"1.0", "1.1"} {
+		_, err := os.Stat(filepath.Join(outputDir, version, "symbols", "parser", "DocParser.ParseDocForDir.html"))
+		assert.NoError(t, err)
+	}
+
+	appConfig, err := app.LoadAppConfig(system.OSFileSystem{}, filepath.Join(outputDir, HTML_APP_FILE))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.0", "1.1"}, appConfig.VersionNames())
+
+	versions, err := os.ReadFile(filepath.Join(outputDir, "versions.js"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(versions), `"versions":["1.0","1.1"]`)
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), "url=1.1/index.html")
+}
+
+// failingRenameFileSystem fails to move the staged content of a given file into place
+type failingRenameFileSystem struct {
+	system.OSFileSystem
+	failOn string
+}
+
+func (f failingRenameFileSystem) Rename(oldPath, newPath string) error {
+	if newPath == f.failOn && strings.HasSuffix(oldPath, system.TRANSACTION_STAGED_SUFFIX) {
+		return errors.New("disk full")
+	}
+	return f.OSFileSystem.Rename(oldPath, newPath)
+}
+
+func TestHTMLExporter_Export_CommitFailure(t *testing.T) {
+	outputDir := t.TempDir()
+	exporter := HTMLExporter{
+		OutputDir:  outputDir,
+		AppName:    "zendoc",
+		Version:    "1.0",
+		FileSystem: system.OSFileSystem{},
+	}
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	assert.NoError(t, err)
+
+	exporter.Version = "1.1"
+	exporter.FileSystem = failingRenameFileSystem{failOn: filepath.Join(outputDir, HTML_APP_FILE)}
+	assert.ErrorContains(t, exporter.Export(context.Background(), doctest.Sample()), "disk full")
+
+	// the previous site is left as it was
+	appConfig, err := app.LoadAppConfig(system.OSFileSystem{}, filepath.Join(outputDir, HTML_APP_FILE))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.0"}, appConfig.VersionNames())
+
+	current, err := os.ReadFile(filepath.Join(outputDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Equal(t, index, current)
+
+	// the folders of the failed version are removed with its pages
+	_, err = os.Stat(filepath.Join(outputDir, "1.1"))
+	assert.True(t, os.IsNotExist(err))
+
+	err = filepath.WalkDir(outputDir, func(path string, entry os.DirEntry, err error) error {
+		assert.False(t, system.IsTransactionFile(path), path)
+		return err
+	})
+	assert.NoError(t, err)
+}
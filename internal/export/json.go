@@ -1,37 +1,134 @@
 package export
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/dterbah/zendoc/internal/doc"
 	"github.com/dterbah/zendoc/internal/parser/serializer"
+	"github.com/dterbah/zendoc/internal/search"
+	"github.com/dterbah/zendoc/internal/system"
 )
 
 /*
 @description Struct that implements the DocExporter interface and exports the documentation in JSON format.
 @author Dorian TERBAH
 @field DocExporter DocExporter - Embedded base exporter providing common exporting behavior.
+@field FileSystem system.FileSystem - The file system used to write the files
+@field Output string - The path of the JSON file, doc.json when empty, or "-" to write the documentation on Stdout
+@field Stdout io.Writer - The writer receiving the documentation when the output is "-"
+@field Gzip bool - Value used to compress the documentation with gzip
+@field Compact bool - Value used to write the JSON on a single line instead of indenting it
 */
 type JSONExporter struct {
 	DocExporter
+	FileSystem system.FileSystem
+	Output     string
+	Stdout     io.Writer
+	Gzip       bool
+	Compact    bool
 }
 
 const EXPORT_FILE = "doc.json"
+const GZIP_EXPORT_FILE = EXPORT_FILE + ".gz"
+
+// Output writing the JSON documentation on the standard output
+const STDOUT_OUTPUT = "-"
 
 /*
-@description Export the project documentation to a JSON file
+@description Export the project documentation to a JSON file, next to its search index, or on the standard output without index
+@param ctx context.Context - The context of the export
 @param projectDoc doc.ProjectDoc - The documentation to export
-@return error - An error if writing to file fails
+@return error - An error if the serialization or the writing fails
 @author Dorian TERBAH
-@example JSONExporter{}.Export(projectDoc)
+@example JSONExporter{FileSystem: system.OSFileSystem{}, Output: "doc/api.json", Compact: true}.Export(ctx, projectDoc)
 */
-func (jsonExport JSONExporter) Export(projectDoc doc.ProjectDoc) error {
-	projectDocJSON, err := serializer.SerializeToJSON(projectDoc)
-
+func (jsonExport JSONExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	content, err := jsonExport.encode(projectDoc)
 	if err != nil {
+		return err
+	}
+
+	if jsonExport.Output == STDOUT_OUTPUT {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := jsonExport.Stdout.Write(content); err != nil {
+			return fmt.Errorf("error when writing the documentation: %w", err)
+		}
 		return nil
 	}
 
-	err = os.WriteFile(EXPORT_FILE, []byte(projectDocJSON), 0644)
-	return err
+	index, err := search.Build(projectDoc).MarshalCompact()
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// the documentation and its index are replaced together, so that they always describe the same sources
+	transaction := system.NewTransaction(jsonExport.FileSystem)
+	defer transaction.Rollback()
+
+	outputFiles := jsonExport.OutputFiles()
+	if err := transaction.MkdirAll(filepath.Dir(outputFiles[0]), os.ModePerm); err != nil {
+		return fmt.Errorf("error when creating the folder of %s: %w", outputFiles[0], err)
+	}
+
+	if err := transaction.WriteFile(outputFiles[0], content, 0644); err != nil {
+		return fmt.Errorf("error when saving %s: %w", outputFiles[0], err)
+	}
+	if err := transaction.WriteFile(outputFiles[1], index, 0644); err != nil {
+		return fmt.Errorf("error when saving %s: %w", outputFiles[1], err)
+	}
+
+	return transaction.Commit()
+}
+
+/*
+@description Give the files written by the export: the documentation followed by its search index, written in the same folder
+@return []string - The paths of the files, empty when the documentation is written on the standard output
+@example JSONExporter{Output: "doc/api.json"}.OutputFiles() => ["doc/api.json", "doc/search-index.json"]
+@author Dorian TERBAH
+*/
+func (jsonExport JSONExporter) OutputFiles() []string {
+	output := jsonExport.Output
+	switch {
+	case output == STDOUT_OUTPUT:
+		return []string{}
+	case output == "" && jsonExport.Gzip:
+		output = GZIP_EXPORT_FILE
+	case output == "":
+		output = EXPORT_FILE
+	}
+
+	return []string{output, filepath.Join(filepath.Dir(output), search.INDEX_FILE)}
+}
+
+// encode serializes the documentation, compressing it when gzip is enabled
+func (jsonExport JSONExporter) encode(projectDoc doc.ProjectDoc) ([]byte, error) {
+	var buf bytes.Buffer
+	if !jsonExport.Gzip {
+		if err := serializer.WriteJSON(&buf, projectDoc, !jsonExport.Compact); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	writer := gzip.NewWriter(&buf)
+	if err := serializer.WriteJSON(writer, projectDoc, !jsonExport.Compact); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("error when compressing the documentation: %w", err)
+	}
+
+	return buf.Bytes(), nil
 }
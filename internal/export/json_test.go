@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONExporter_Export(t *testing.T) {
+	memoryFs := system.NewMemoryFileSystem()
+	exporter := JSONExporter{FileSystem: memoryFs, Output: "doc/api.json", Compact: true}
+
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	content, err := memoryFs.ReadFile("doc/api.json")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(content), "\n"))
+	projectDoc, err := doc.LoadProjectDoc(bytes.NewReader(content))
+	assert.NoError(t, err)
+	assert.Contains(t, projectDoc.PackageDocs, "parser")
+
+	assert.True(t, memoryFs.FileExists("doc/search-index.json"))
+	assert.False(t, memoryFs.FileExists(EXPORT_FILE))
+}
+
+func TestJSONExporter_Export_Gzip(t *testing.T) {
+	memoryFs := system.NewMemoryFileSystem()
+	exporter := JSONExporter{FileSystem: memoryFs, Gzip: true}
+
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	content, err := memoryFs.ReadFile(GZIP_EXPORT_FILE)
+	assert.NoError(t, err)
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	assert.NoError(t, err)
+	uncompressed, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Contains(t, string(uncompressed), "\n  \"packageDocs\"")
+}
+
+func TestJSONExporter_Export_Stdout(t *testing.T) {
+	memoryFs := system.NewMemoryFileSystem()
+	var stdout bytes.Buffer
+	exporter := JSONExporter{FileSystem: memoryFs, Output: STDOUT_OUTPUT, Stdout: &stdout}
+
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+	assert.Contains(t, stdout.String(), `"packageDocs"`)
+	assert.False(t, memoryFs.FileExists(EXPORT_FILE))
+	assert.Empty(t, exporter.OutputFiles())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stdout.Reset()
+	assert.ErrorIs(t, exporter.Export(ctx, doctest.Sample()), context.Canceled)
+	assert.Empty(t, stdout.String())
+}
+
+func TestJSONExporter_OutputFiles(t *testing.T) {
+	assert.Equal(t, []string{EXPORT_FILE, "search-index.json"}, JSONExporter{}.OutputFiles())
+	assert.Equal(t, []string{filepath.Join("out", "api.json"), filepath.Join("out", "search-index.json")}, JSONExporter{Output: filepath.Join("out", "api.json")}.OutputFiles())
+}
+
+func TestFileWatcher_ShouldIgnore(t *testing.T) {
+	watcher := FileWatcher{IgnoredFiles: JSONExporter{Output: filepath.Join("out", "api.json")}.OutputFiles()}
+
+	assert.True(t, watcher.shouldIgnore(filepath.Join("doc", "app.json"), "doc"))
+	assert.True(t, watcher.shouldIgnore(filepath.Join("out", "api.json"), "doc"))
+	assert.True(t, watcher.shouldIgnore(filepath.Join("out", "search-index.json"), "doc"))
+	assert.True(t, watcher.shouldIgnore(filepath.Join("pkg", ".add.go"+system.TRANSACTION_STAGED_SUFFIX), "doc"))
+	assert.False(t, watcher.shouldIgnore(EXPORT_FILE, "doc"))
+	assert.False(t, watcher.shouldIgnore(filepath.Join("pkg", "add.go"), "doc"))
+}
@@ -0,0 +1,174 @@
+package export
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/system"
+)
+
+const JSONL_EXPORT_FILE = "doc.jsonl"
+const GZIP_JSONL_EXPORT_FILE = JSONL_EXPORT_FILE + ".gz"
+
+/*
+@description Struct representing a line of the JSON Lines export: a documented symbol with its location
+@author Dorian TERBAH
+@field ID string - The unique identifier of the symbol (e.g. parser.DocParser.ParseDocForDir)
+@field Package string - The package declaring the symbol
+@field File string - The path of the file declaring the symbol
+@field Kind string - The type of the symbol (e.g. 'function', 'struct')
+@field Symbol doc.DocItem - The documentation of the symbol
+*/
+type JSONLRecord struct {
+	ID      string      `json:"id"`
+	Package string      `json:"package"`
+	File    string      `json:"file"`
+	Kind    string      `json:"kind"`
+	Symbol  doc.DocItem `json:"symbol"`
+}
+
+/*
+@description Struct that implements the StreamExporter interface and exports the documentation in the JSON Lines format, one line per symbol, written as soon as its file is parsed
+@author Dorian TERBAH
+@field FileSystem system.FileSystem - The file system used to write the file
+@field Output string - The path of the file, doc.jsonl when empty, or "-" to write the lines on Stdout
+@field Stdout io.Writer - The writer receiving the lines when the output is "-"
+@field Gzip bool - Value used to compress the lines with gzip
+*/
+type JSONLExporter struct {
+	FileSystem system.FileSystem
+	Output     string
+	Stdout     io.Writer
+	Gzip       bool
+}
+
+/*
+@description Export a documentation already parsed, package by package
+@param ctx context.Context - The context of the export
+@param projectDoc doc.ProjectDoc - The documentation to export
+@return error - An error if the writing fails
+@example JSONLExporter{FileSystem: system.OSFileSystem{}}.Export(ctx, projectDoc)
+@author Dorian TERBAH
+*/
+func (jsonlExport JSONLExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	return jsonlExport.ExportStream(ctx, ProjectDocStream(projectDoc))
+}
+
+/*
+@description Write a line for each symbol of the files given by the stream. The file only replaces the previous export once the stream is complete, while the lines written on the standard output cannot be taken back when the stream fails.
+@param ctx context.Context - The context of the export, checked before each file
+@param stream DocStream - The source of the documentation
+@return error - An error if the stream fails, if the writing fails or if the context is done
+@example JSONLExporter{FileSystem: system.OSFileSystem{}, Output: "-", Stdout: os.Stdout}.ExportStream(ctx, stream)
+@author Dorian TERBAH
+*/
+func (jsonlExport JSONLExporter) ExportStream(ctx context.Context, stream DocStream) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if jsonlExport.Output == STDOUT_OUTPUT {
+		return jsonlExport.write(ctx, jsonlExport.Stdout, stream)
+	}
+
+	output := jsonlExport.outputFile()
+	transaction := system.NewTransaction(jsonlExport.FileSystem)
+	defer transaction.Rollback()
+
+	if err := transaction.MkdirAll(filepath.Dir(output), os.ModePerm); err != nil {
+		return fmt.Errorf("error when creating the folder of %s: %w", output, err)
+	}
+
+	file, err := transaction.Create(output, 0644)
+	if err != nil {
+		return fmt.Errorf("error when creating %s: %w", output, err)
+	}
+
+	err = jsonlExport.write(ctx, file, stream)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("error when saving %s: %w", output, closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+/*
+@description Give the files written by the export
+@return []string - The path of the file, empty when the lines are written on the standard output
+@example JSONLExporter{Gzip: true}.OutputFiles() => ["doc.jsonl.gz"]
+@author Dorian TERBAH
+*/
+func (jsonlExport JSONLExporter) OutputFiles() []string {
+	if jsonlExport.Output == STDOUT_OUTPUT {
+		return []string{}
+	}
+	return []string{jsonlExport.outputFile()}
+}
+
+func (jsonlExport JSONLExporter) outputFile() string {
+	switch {
+	case jsonlExport.Output != "":
+		return jsonlExport.Output
+	case jsonlExport.Gzip:
+		return GZIP_JSONL_EXPORT_FILE
+	default:
+		return JSONL_EXPORT_FILE
+	}
+}
+
+// write encodes the records of the stream through a buffer, so that the symbols are not written one by one
+func (jsonlExport JSONLExporter) write(ctx context.Context, writer io.Writer, stream DocStream) error {
+	var gzipWriter *gzip.Writer
+	if jsonlExport.Gzip {
+		gzipWriter = gzip.NewWriter(writer)
+		writer = gzipWriter
+	}
+
+	buffer := bufio.NewWriter(writer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+
+	err := stream(func(pckName string, fileDoc doc.FileDoc) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for _, symbol := range doc.FileSymbols(pckName, fileDoc) {
+			record := JSONLRecord{
+				ID:      symbol.ID,
+				Package: symbol.Package,
+				File:    fileDoc.Path,
+				Kind:    symbol.Item.GetBaseDoc().Type,
+				Symbol:  symbol.Item,
+			}
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("error when exporting %s in JSON: %w", symbol.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := buffer.Flush(); err != nil {
+		return fmt.Errorf("error when writing the documentation: %w", err)
+	}
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			return fmt.Errorf("error when compressing the documentation: %w", err)
+		}
+	}
+
+	return nil
+}
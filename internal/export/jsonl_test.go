@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/export/source"
+	"github.com/dterbah/zendoc/internal/parser"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+// readRecords decodes the lines of a JSON Lines export
+func readRecords(t *testing.T, content []byte) []map[string]any {
+	records := []map[string]any{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		record := map[string]any{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestJSONLExporter_Export(t *testing.T) {
+	memoryFs := system.NewMemoryFileSystem()
+	exporter := JSONLExporter{FileSystem: memoryFs, Output: "doc/api.jsonl"}
+
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	content, err := memoryFs.ReadFile("doc/api.jsonl")
+	assert.NoError(t, err)
+	records := readRecords(t, content)
+	assert.Len(t, records, 9)
+	assert.Equal(t, "parser.DocParser.ParseDocForDir", records[5]["id"])
+	assert.Equal(t, "parser", records[5]["package"])
+	assert.Equal(t, "internal/parser/parser.go", records[5]["file"])
+	assert.Equal(t, doc.FUNCTION_TYPE, records[5]["kind"])
+	assert.Equal(t, "Recursively parse a directory", records[5]["symbol"].(map[string]any)["description"])
+	assert.Equal(t, doc.STRUCT_TYPE, records[4]["kind"])
+}
+
+func TestJSONLExporter_ExportStream_Failure(t *testing.T) {
+	memoryFs := system.NewMemoryFileSystem()
+	assert.NoError(t, memoryFs.WriteFile(JSONL_EXPORT_FILE, []byte("previous\n"), 0644))
+	exporter := JSONLExporter{FileSystem: memoryFs}
+
+	failure := errors.New("parse failure")
+	err := exporter.ExportStream(context.Background(), func(visit parser.FileDocVisitor) error {
+		if err := visit("parser", doctest.Sample().PackageDocs["parser"][0]); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	// the previous export is kept when the stream fails
+	content, err := memoryFs.ReadFile(JSONL_EXPORT_FILE)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous\n", string(content))
+	assert.False(t, memoryFs.FileExists("."+JSONL_EXPORT_FILE+system.TRANSACTION_STAGED_SUFFIX))
+}
+
+func TestJSONLExporter_ExportStream_Stdout(t *testing.T) {
+	var stdout bytes.Buffer
+	links, err := source.NewLinkBuilder("https://github.com/a/b", "", "main", false)
+	assert.NoError(t, err)
+	exporter := LinkedExporter{
+		Exporter: JSONLExporter{Output: STDOUT_OUTPUT, Stdout: &stdout},
+		Links:    *links,
+	}
+
+	assert.NoError(t, exporter.ExportStream(context.Background(), ProjectDocStream(doctest.Sample())))
+	assert.Equal(t, 9, strings.Count(stdout.String(), "\n"))
+	assert.Contains(t, stdout.String(), `"sourceLink":"https://github.com/a/b/blob/main/internal/parser/parser.go#L74-L123"`)
+
+	assert.Error(t, LinkedExporter{Exporter: JSONExporter{}, Links: *links}.ExportStream(context.Background(), ProjectDocStream(doctest.Sample())))
+}
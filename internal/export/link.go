@@ -0,0 +1,54 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/source"
+	"github.com/dterbah/zendoc/internal/parser"
+)
+
+/*
+@description Struct that implements the DocExporter interface and adds source links to the documentation before delegating the export to another exporter
+@author Dorian TERBAH
+@field Exporter DocExporter - The exporter receiving the annotated documentation
+@field Links source.LinkBuilder - The builder used to create the permalinks of the documented items
+*/
+type LinkedExporter struct {
+	Exporter DocExporter
+	Links    source.LinkBuilder
+}
+
+/*
+@description Annotate the documentation with source links and export it
+@param ctx context.Context - The context of the export
+@param projectDoc doc.ProjectDoc - The documentation to export
+@return error - An error if the wrapped exporter fails
+@author Dorian TERBAH
+*/
+func (linkedExport LinkedExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	linkedExport.Links.Annotate(&projectDoc)
+	return linkedExport.Exporter.Export(ctx, projectDoc)
+}
+
+/*
+@description Annotate each file of the stream with source links as it is parsed, and export it with the wrapped exporter, which must be a StreamExporter
+@param ctx context.Context - The context of the export
+@param stream DocStream - The source of the documentation
+@return error - An error if the wrapped exporter cannot stream or fails
+@author Dorian TERBAH
+*/
+func (linkedExport LinkedExporter) ExportStream(ctx context.Context, stream DocStream) error {
+	streamExporter, ok := linkedExport.Exporter.(StreamExporter)
+	if !ok {
+		return fmt.Errorf("the exporter does not support streaming")
+	}
+
+	return streamExporter.ExportStream(ctx, func(visit parser.FileDocVisitor) error {
+		return stream(func(pckName string, fileDoc doc.FileDoc) error {
+			linkedExport.Links.AnnotateFile(fileDoc)
+			return visit(pckName, fileDoc)
+		})
+	})
+}
@@ -0,0 +1,280 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/helper"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
+)
+
+const MARKDOWN_DIR = "markdown"
+const MARKDOWN_INDEX_FILE = "README.md"
+
+/*
+@description Struct that implements the DocExporter interface and exports the documentation as Markdown files, one per package plus an index
+@author Dorian TERBAH
+@field OutputDir string - The directory where the Markdown files are written
+@field AppName string - The name of the project, used as title of the index
+@field Description string - The description of the project, displayed in the index
+@field FileSystem system.FileSystem - The file system used to write the files
+*/
+type MarkdownExporter struct {
+	DocExporter
+	OutputDir   string
+	AppName     string
+	Description string
+	FileSystem  system.FileSystem
+}
+
+/*
+@description Export the project documentation as Markdown files
+@param ctx context.Context - The context of the export
+@param projectDoc doc.ProjectDoc - The documentation to export
+@return error - An error if a file cannot be written
+@example MarkdownExporter{OutputDir: "doc/markdown", FileSystem: system.OSFileSystem{}}.Export(ctx, projectDoc)
+@author Dorian TERBAH
+*/
+func (markdownExport MarkdownExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	packages := helper.SortedPackages(projectDoc)
+	contents := make(map[string]string, len(packages))
+	for _, pckName := range packages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		contents[pckName] = markdownExport.renderPackage(pckName, projectDoc.PackageDocs[pckName])
+	}
+	index := markdownExport.renderIndex(projectDoc, packages)
+
+	// every file is rendered before writing anything, so that a cancelled export leaves the output untouched
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	transaction := system.NewTransaction(markdownExport.FileSystem)
+	defer transaction.Rollback()
+
+	if err := transaction.MkdirAll(markdownExport.OutputDir, os.ModePerm); err != nil {
+		return fmt.Errorf("error when creating the markdown directory: %w", err)
+	}
+
+	for _, pckName := range packages {
+		if err := markdownExport.writeFile(transaction, markdownPackageFile(pckName), contents[pckName]); err != nil {
+			return err
+		}
+	}
+
+	if err := markdownExport.writeFile(transaction, MARKDOWN_INDEX_FILE, index); err != nil {
+		return err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return fmt.Errorf("error when saving the markdown documentation: %w", err)
+	}
+
+	color.Green("Markdown documentation saved in %s!", markdownExport.OutputDir)
+	return nil
+}
+
+func (markdownExport MarkdownExporter) writeFile(transaction *system.Transaction, name, content string) error {
+	path := filepath.Join(markdownExport.OutputDir, name)
+	if err := transaction.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("error when saving the markdown file %s: %w", path, err)
+	}
+	return nil
+}
+
+func markdownPackageFile(pckName string) string {
+	return pckName + ".md"
+}
+
+// renderIndex lists the packages of the project with their number of types and functions
+func (markdownExport MarkdownExporter) renderIndex(projectDoc doc.ProjectDoc, packages []string) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# %s\n\n", markdownExport.AppName)
+	if markdownExport.Description != "" {
+		fmt.Fprintf(&builder, "%s\n\n", markdownExport.Description)
+	}
+
+	builder.WriteString("| Package | Types | Functions |\n")
+	builder.WriteString("| --- | --- | --- |\n")
+	for _, pckName := range packages {
+		types, functions := 0, 0
+		for _, item := range helper.SortedItems(projectDoc.PackageDocs[pckName]) {
+			if _, ok := item.Item.(*doc.FuncDoc); ok {
+				functions++
+			} else {
+				types++
+			}
+		}
+		fmt.Fprintf(&builder, "| [%s](%s) | %d | %d |\n", pckName, markdownPackageFile(pckName), types, functions)
+	}
+
+	return builder.String()
+}
+
+// renderPackage renders the types of a package, each followed by its methods, then the remaining functions
+func (markdownExport MarkdownExporter) renderPackage(pckName string, files []doc.FileDoc) string {
+	var builder strings.Builder
+
+	items := helper.SortedItems(files)
+	types := []helper.PackageItem{}
+	functions := []helper.PackageItem{}
+	methods := map[string][]helper.PackageItem{}
+
+	documentedTypes := map[string]bool{}
+	for _, item := range items {
+		if _, isFunction := item.Item.(*doc.FuncDoc); !isFunction {
+			types = append(types, item)
+			documentedTypes[item.Item.GetBaseDoc().Name] = true
+		}
+	}
+
+	// methods of undocumented structs are listed with the functions
+	for _, item := range items {
+		fd, isFunction := item.Item.(*doc.FuncDoc)
+		if !isFunction {
+			continue
+		}
+		if fd.Struct != "" && documentedTypes[fd.Struct] {
+			methods[fd.Struct] = append(methods[fd.Struct], item)
+		} else {
+			functions = append(functions, item)
+		}
+	}
+
+	fmt.Fprintf(&builder, "# Package %s\n\n", pckName)
+	fmt.Fprintf(&builder, "[Back to index](%s)\n\n", MARKDOWN_INDEX_FILE)
+
+	builder.WriteString("## Index\n\n")
+	for _, item := range types {
+		name := item.Item.GetBaseDoc().Name
+		fmt.Fprintf(&builder, "- [%s](#%s)\n", name, helper.Anchor(name))
+		for _, method := range methods[name] {
+			methodName := helper.ItemName(method.Item)
+			fmt.Fprintf(&builder, "  - [%s](#%s)\n", methodName, helper.Anchor(methodName))
+		}
+	}
+	for _, item := range functions {
+		name := helper.ItemName(item.Item)
+		fmt.Fprintf(&builder, "- [%s](#%s)\n", name, helper.Anchor(name))
+	}
+	builder.WriteString("\n")
+
+	if len(types) > 0 {
+		builder.WriteString("## Types\n")
+		for _, item := range types {
+			renderMarkdownItem(&builder, "###", item)
+			for _, method := range methods[item.Item.GetBaseDoc().Name] {
+				renderMarkdownItem(&builder, "####", method)
+			}
+		}
+	}
+
+	if len(functions) > 0 {
+		builder.WriteString("\n## Functions\n")
+		for _, item := range functions {
+			renderMarkdownItem(&builder, "###", item)
+		}
+	}
+
+	return builder.String()
+}
+
+// renderMarkdownItem renders the heading, signature, description and tables of a documented item
+func renderMarkdownItem(builder *strings.Builder, heading string, packageItem helper.PackageItem) {
+	item := packageItem.Item
+	baseDoc := item.GetBaseDoc()
+
+	fmt.Fprintf(builder, "\n%s %s\n\n", heading, helper.ItemName(item))
+
+	if baseDoc.Deprecated != "" {
+		fmt.Fprintf(builder, "> **Deprecated:** %s\n\n", markdownInline(baseDoc.Deprecated))
+	}
+
+	fmt.Fprintf(builder, "```go\n%s\n```\n\n", helper.Signature(item))
+
+	if baseDoc.Description != "" {
+		fmt.Fprintf(builder, "%s\n\n", baseDoc.Description)
+	}
+
+	switch d := item.(type) {
+	case *doc.FuncDoc:
+		renderMarkdownFunction(builder, *d)
+	case *doc.StructDoc:
+		if len(d.Fields) > 0 {
+			renderMarkdownTable(builder, "Field", d.Fields)
+		}
+	case *doc.InterfaceDoc:
+		for _, method := range d.Methods {
+			fmt.Fprintf(builder, "**%s**\n\n", method.Name)
+			if method.Deprecated != "" {
+				fmt.Fprintf(builder, "> **Deprecated:** %s\n\n", markdownInline(method.Deprecated))
+			}
+			if method.Description != "" {
+				fmt.Fprintf(builder, "%s\n\n", method.Description)
+			}
+			renderMarkdownFunction(builder, method)
+		}
+	}
+
+	renderMarkdownFooter(builder, packageItem.File, *baseDoc)
+}
+
+func renderMarkdownFunction(builder *strings.Builder, fd doc.FuncDoc) {
+	if len(fd.Params) > 0 {
+		renderMarkdownTable(builder, "Param", fd.Params)
+	}
+
+	if fd.Return != nil {
+		builder.WriteString("| Returns | Description |\n")
+		builder.WriteString("| --- | --- |\n")
+		fmt.Fprintf(builder, "| `%s` | %s |\n\n", markdownCell(fd.Return.Type), markdownCell(fd.Return.Description))
+	}
+
+	if fd.Example != "" {
+		fmt.Fprintf(builder, "Example:\n\n```go\n%s\n```\n\n", fd.Example)
+	}
+}
+
+func renderMarkdownTable(builder *strings.Builder, title string, params []doc.Param) {
+	fmt.Fprintf(builder, "| %s | Type | Description |\n", title)
+	builder.WriteString("| --- | --- | --- |\n")
+	for _, param := range params {
+		fmt.Fprintf(builder, "| %s | `%s` | %s |\n", markdownCell(param.Name), markdownCell(param.Type), markdownCell(param.Description))
+	}
+	builder.WriteString("\n")
+}
+
+func renderMarkdownFooter(builder *strings.Builder, file doc.FileDoc, baseDoc doc.BaseDoc) {
+	location := file.Path
+	if baseDoc.Position.StartLine > 0 {
+		location = fmt.Sprintf("%s:%d", file.Path, baseDoc.Position.StartLine)
+	}
+
+	if baseDoc.SourceLink != "" {
+		fmt.Fprintf(builder, "Source: [%s](%s)", location, baseDoc.SourceLink)
+	} else {
+		fmt.Fprintf(builder, "Source: `%s`", location)
+	}
+
+	if baseDoc.Author != "" {
+		fmt.Fprintf(builder, " · Author: %s", markdownInline(baseDoc.Author))
+	}
+	builder.WriteString("\n")
+}
+
+// markdownCell escapes a value so that it fits in a table cell
+func markdownCell(value string) string {
+	return strings.ReplaceAll(markdownInline(value), "|", "\\|")
+}
+
+func markdownInline(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+// writtenFiles lists the files of a memory file system, to check that a failed export writes nothing
+func writtenFiles(t *testing.T, fileSystem *system.MemoryFileSystem) []string {
+	files := []string{}
+	err := fs.WalkDir(fileSystem, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			files = append(files, name)
+		}
+		return err
+	})
+	assert.NoError(t, err)
+	return files
+}
+
+func TestMarkdownExporter_Export(t *testing.T) {
+	fileSystem := system.NewMemoryFileSystem()
+	exporter := MarkdownExporter{
+		OutputDir:   "out",
+		AppName:     "zendoc",
+		Description: "Doc generator",
+		FileSystem:  fileSystem,
+	}
+
+	err := exporter.Export(context.Background(), doctest.Sample())
+	assert.NoError(t, err)
+
+	index, err := fileSystem.ReadFile("out/" + MARKDOWN_INDEX_FILE)
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), "# zendoc")
+	assert.Contains(t, string(index), "| [parser](parser.md) | 1 | 2 |")
+
+	markdown, err := fileSystem.ReadFile("out/parser.md")
+	assert.NoError(t, err)
+	content := string(markdown)
+	assert.Contains(t, content, "- [DocParser](#docparser)\n  - [DocParser.ParseDocForDir](#docparserparsedocfordir)\n- [getPackageName](#getpackagename)")
+	assert.Contains(t, content, "### DocParser\n")
+	assert.Contains(t, content, "#### DocParser.ParseDocForDir\n")
+	assert.Contains(t, content, "```go\nfunc (DocParser) ParseDocForDir(dirPath string) (*doc.ProjectDoc, error)\n```")
+	assert.Contains(t, content, "| dirPath | `string` | The root \\| path |")
+	assert.Contains(t, content, "| FileValidators | `[]DocParserFileValidator` | File validators |")
+	assert.Contains(t, content, "> **Deprecated:** Use the AST")
+	assert.Contains(t, content, "Source: [internal/parser/parser.go:29](https://github.com/a/b/blob/main/internal/parser/parser.go#L29-L32)")
+	assert.Contains(t, content, "Source: [internal/parser/parser.go:74](https://github.com/a/b/blob/main/internal/parser/parser.go#L74-L123) · Author: Dorian TERBAH")
+	assert.Contains(t, content, "Source: `internal/parser/parser.go:130`")
+}
+
+func TestMarkdownExporter_Export_Cancelled(t *testing.T) {
+	fileSystem := system.NewMemoryFileSystem()
+	exporter := MarkdownExporter{OutputDir: "out", AppName: "zendoc", FileSystem: fileSystem}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, exporter.Export(ctx, doctest.Sample()), context.Canceled)
+	assert.Empty(t, writtenFiles(t, fileSystem))
+}
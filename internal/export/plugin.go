@@ -0,0 +1,178 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dterbah/zendoc/config"
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
+)
+
+// Version of the protocol spoken with the plugins. Bump it on any incompatible change of PluginRequest or PluginResponse.
+const PLUGIN_PROTOCOL_VERSION = 1
+
+const PLUGIN_BASE64_ENCODING = "base64"
+
+/*
+@description Struct to represent the request written as JSON on the standard input of a plugin
+@author Dorian TERBAH
+@field ProtocolVersion int - The version of the plugin protocol spoken by zendoc
+@field Config config.Config - The ZenDoc configuration of the project
+@field Doc doc.ProjectDoc - The documentation to export
+*/
+type PluginRequest struct {
+	ProtocolVersion int            `json:"protocolVersion"`
+	Config          config.Config  `json:"config"`
+	Doc             doc.ProjectDoc `json:"doc"`
+}
+
+/*
+@description Struct to represent a file to write, returned by a plugin
+@author Dorian TERBAH
+@field Path string - The path of the file, relative to the output directory
+@field Content string - The content of the file
+@field Encoding string - Empty for text content, or "base64" for binary content
+*/
+type PluginFile struct {
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+/*
+@description Struct to represent the response read as JSON from the standard output of a plugin
+@author Dorian TERBAH
+@field ProtocolVersion int - The version of the plugin protocol spoken by the plugin
+@field Files []PluginFile - The files to write
+@field Error string - An error message if the plugin failed
+*/
+type PluginResponse struct {
+	ProtocolVersion int          `json:"protocolVersion"`
+	Files           []PluginFile `json:"files"`
+	Error           string       `json:"error,omitempty"`
+}
+
+/*
+@description Struct that implements the DocExporter interface and delegates the export to an external executable, like protoc plugins. The plugin receives a PluginRequest on its standard input and answers with a PluginResponse on its standard output.
+@author Dorian TERBAH
+@field Name string - The name of the plugin executable, looked up in the PATH
+@field Config config.Config - The ZenDoc configuration sent to the plugin
+@field OutputDir string - The directory where the files returned by the plugin are written
+@field FileSystem system.FileSystem - The file system used to write the files
+@field CmdRunner system.CommandRunner - The runner used to execute the plugin
+*/
+type PluginExporter struct {
+	DocExporter
+	Name       string
+	Config     config.Config
+	OutputDir  string
+	FileSystem system.FileSystem
+	CmdRunner  system.CommandRunner
+}
+
+/*
+@description Run the plugin on the project documentation and write the files it returns
+@param ctx context.Context - The context of the export
+@param projectDoc doc.ProjectDoc - The documentation to export
+@return error - An error if the plugin fails, speaks another protocol version or returns an invalid file
+@example PluginExporter{Name: "zendoc-gen-confluence", OutputDir: "doc", FileSystem: system.OSFileSystem{}, CmdRunner: system.OSCommandRunner{}}.Export(ctx, projectDoc)
+@author Dorian TERBAH
+*/
+func (pluginExport PluginExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	if projectDoc.SchemaVersion == "" {
+		projectDoc.SchemaVersion = doc.SCHEMA_VERSION
+	}
+
+	request, err := json.Marshal(PluginRequest{
+		ProtocolVersion: PLUGIN_PROTOCOL_VERSION,
+		Config:          pluginExport.Config,
+		Doc:             projectDoc,
+	})
+	if err != nil {
+		return fmt.Errorf("error when serializing the plugin request: %w", err)
+	}
+
+	color.Cyan("Running plugin %s...", pluginExport.Name)
+	output, runErr := pluginExport.CmdRunner.ExecuteWithInput(ctx, "", request, pluginExport.Name)
+
+	var response PluginResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		if runErr != nil {
+			return fmt.Errorf("error when running the plugin %s: %w", pluginExport.Name, runErr)
+		}
+		return fmt.Errorf("invalid response from the plugin %s: %w", pluginExport.Name, err)
+	}
+
+	if response.Error != "" {
+		return fmt.Errorf("plugin %s failed: %s", pluginExport.Name, response.Error)
+	}
+	if runErr != nil {
+		return fmt.Errorf("error when running the plugin %s: %w", pluginExport.Name, runErr)
+	}
+	if response.ProtocolVersion != PLUGIN_PROTOCOL_VERSION {
+		return fmt.Errorf("plugin %s speaks the protocol version %d, but zendoc expects version %d", pluginExport.Name, response.ProtocolVersion, PLUGIN_PROTOCOL_VERSION)
+	}
+
+	// every file is checked before writing anything, so that an invalid response leaves the output untouched
+	contents := make(map[string][]byte, len(response.Files))
+	for _, file := range response.Files {
+		target, content, err := pluginExport.decodeFile(file)
+		if err != nil {
+			return err
+		}
+		contents[target] = content
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	transaction := system.NewTransaction(pluginExport.FileSystem)
+	defer transaction.Rollback()
+
+	for target, content := range contents {
+		if err := transaction.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return fmt.Errorf("error when creating the folder of %s: %w", target, err)
+		}
+		if err := transaction.WriteFile(target, content, 0644); err != nil {
+			return fmt.Errorf("error when saving %s: %w", target, err)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return fmt.Errorf("error when saving the files of the plugin %s: %w", pluginExport.Name, err)
+	}
+
+	color.Green("Plugin %s wrote %d file(s) in %s!", pluginExport.Name, len(response.Files), pluginExport.OutputDir)
+	return nil
+}
+
+// decodeFile computes the target path and the content of a file returned by the plugin, refusing paths outside of the output directory
+func (pluginExport PluginExporter) decodeFile(file PluginFile) (string, []byte, error) {
+	relativePath := filepath.Clean(filepath.FromSlash(file.Path))
+	if file.Path == "" || filepath.IsAbs(relativePath) || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", nil, fmt.Errorf("plugin %s returned an invalid path \"%s\"", pluginExport.Name, file.Path)
+	}
+
+	content := []byte(file.Content)
+	switch file.Encoding {
+	case "":
+	case PLUGIN_BASE64_ENCODING:
+		decoded, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return "", nil, fmt.Errorf("plugin %s returned an invalid base64 content for %s: %w", pluginExport.Name, file.Path, err)
+		}
+		content = decoded
+	default:
+		return "", nil, fmt.Errorf("plugin %s returned an unknown encoding \"%s\" for %s", pluginExport.Name, file.Encoding, file.Path)
+	}
+
+	return filepath.Join(pluginExport.OutputDir, relativePath), content, nil
+}
@@ -0,0 +1,94 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dterbah/zendoc/config"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePluginRunner records the request sent to the plugin and answers with a canned output
+type fakePluginRunner struct {
+	name   string
+	input  []byte
+	output []byte
+	err    error
+}
+
+func (f *fakePluginRunner) Execute(ctx context.Context, dir string, name string, arg ...string) ([]byte, error) {
+	return nil, errors.New("unexpected call")
+}
+
+func (f *fakePluginRunner) ExecuteWithInput(ctx context.Context, dir string, input []byte, name string, arg ...string) ([]byte, error) {
+	f.name = name
+	f.input = input
+	return f.output, f.err
+}
+
+func newPluginExporter(runner *fakePluginRunner, fileSystem *system.MemoryFileSystem) PluginExporter {
+	return PluginExporter{
+		Name:       "zendoc-gen-test",
+		Config:     config.Config{ProjectConfig: config.ProjectConfig{Name: "zendoc", Version: "1.0.0"}},
+		OutputDir:  "out",
+		FileSystem: fileSystem,
+		CmdRunner:  runner,
+	}
+}
+
+func TestPluginExporter_Export(t *testing.T) {
+	runner := &fakePluginRunner{output: []byte(`{"protocolVersion":1,"files":[
+		{"path":"index.txt","content":"hello"},
+		{"path":"pkg/logo.bin","content":"AAEC","encoding":"base64"}
+	]}`)}
+	fileSystem := system.NewMemoryFileSystem()
+
+	err := newPluginExporter(runner, fileSystem).Export(context.Background(), doctest.Sample())
+	assert.NoError(t, err)
+	assert.Equal(t, "zendoc-gen-test", runner.name)
+
+	var request PluginRequest
+	assert.NoError(t, json.Unmarshal(runner.input, &request))
+	assert.Equal(t, PLUGIN_PROTOCOL_VERSION, request.ProtocolVersion)
+	assert.Equal(t, "zendoc", request.Config.ProjectConfig.Name)
+	assert.NotEmpty(t, request.Doc.SchemaVersion)
+	assert.Contains(t, request.Doc.PackageDocs, "parser")
+
+	content, err := fileSystem.ReadFile("out/index.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+	content, err = fileSystem.ReadFile("out/pkg/logo.bin")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2}, content)
+}
+
+func TestPluginExporter_Export_Errors(t *testing.T) {
+	tests := map[string]struct {
+		output   string
+		err      error
+		expected string
+	}{
+		"plugin error":      {output: `{"protocolVersion":1,"error":"boom"}`, err: errors.New("exit status 1"), expected: "plugin zendoc-gen-test failed: boom"},
+		"exit without json": {output: ``, err: errors.New("exit status 2"), expected: "exit status 2"},
+		"invalid json":      {output: `not json`, expected: "invalid response"},
+		"protocol mismatch": {output: `{"protocolVersion":2,"files":[]}`, expected: "protocol version 2"},
+		"path traversal":    {output: `{"protocolVersion":1,"files":[{"path":"../evil","content":""}]}`, expected: "invalid path"},
+		"absolute path":     {output: `{"protocolVersion":1,"files":[{"path":"/etc/evil","content":""}]}`, expected: "invalid path"},
+		"unknown encoding":  {output: `{"protocolVersion":1,"files":[{"path":"a","content":"","encoding":"hex"}]}`, expected: "unknown encoding"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			runner := &fakePluginRunner{output: []byte(test.output), err: test.err}
+			fileSystem := system.NewMemoryFileSystem()
+
+			err := newPluginExporter(runner, fileSystem).Export(context.Background(), doctest.Sample())
+			assert.ErrorContains(t, err, test.expected)
+			assert.Empty(t, writtenFiles(t, fileSystem))
+		})
+	}
+}
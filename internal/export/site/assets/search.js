@@ -0,0 +1,125 @@
+(function () {
+  var root = document.body.getAttribute("data-root") || "";
+  var currentVersion = document.body.getAttribute("data-version") || "";
+
+  // Version switcher, filled from versions.js when the site holds several versions
+  var switcher = document.getElementById("version-switcher");
+  var versions = window.ZENDOC_VERSIONS;
+  if (switcher && versions && versions.versions && versions.versions.length > 0) {
+    versions.versions.forEach(function (version) {
+      var option = document.createElement("option");
+      option.value = version;
+      option.textContent = "v" + version + (version === versions.latest ? " (latest)" : "");
+      option.selected = version === currentVersion;
+      switcher.appendChild(option);
+    });
+    switcher.hidden = false;
+    switcher.addEventListener("change", function () {
+      window.location.href = root + "../" + encodeURIComponent(switcher.value) + "/index.html";
+    });
+  }
+
+  // Client-side search over the symbols of the current version
+  var input = document.getElementById("search-input");
+  var results = document.getElementById("search-results");
+  var entries = window.ZENDOC_SEARCH || [];
+  var selected = -1;
+
+  function score(entry, terms) {
+    var name = entry.name.toLowerCase();
+    var description = (entry.description || "").toLowerCase();
+    var total = 0;
+    for (var i = 0; i < terms.length; i++) {
+      var term = terms[i];
+      if (name === term) {
+        total += 10;
+      } else if (name.indexOf(term) === 0) {
+        total += 6;
+      } else if (name.indexOf(term) >= 0) {
+        total += 4;
+      } else if (entry.package.toLowerCase().indexOf(term) >= 0) {
+        total += 2;
+      } else if (description.indexOf(term) >= 0) {
+        total += 1;
+      } else {
+        return 0;
+      }
+    }
+    return total;
+  }
+
+  function render(matches) {
+    results.innerHTML = "";
+    selected = -1;
+    matches.forEach(function (match) {
+      var item = document.createElement("li");
+      var link = document.createElement("a");
+      link.href = root + match.entry.url;
+      link.textContent = match.entry.name;
+      var pck = document.createElement("span");
+      pck.className = "package";
+      pck.textContent = match.entry.package + " · " + match.entry.kind;
+      link.appendChild(pck);
+      item.appendChild(link);
+      results.appendChild(item);
+    });
+    results.hidden = matches.length === 0;
+  }
+
+  function search() {
+    var terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+    if (terms.length === 0) {
+      render([]);
+      return;
+    }
+    var matches = [];
+    entries.forEach(function (entry) {
+      var value = score(entry, terms);
+      if (value > 0) {
+        matches.push({ entry: entry, score: value });
+      }
+    });
+    matches.sort(function (a, b) {
+      return b.score - a.score || a.entry.name.localeCompare(b.entry.name);
+    });
+    render(matches.slice(0, 20));
+  }
+
+  function select(index) {
+    var items = results.querySelectorAll("li");
+    if (items.length === 0) {
+      return;
+    }
+    if (selected >= 0 && items[selected]) {
+      items[selected].classList.remove("selected");
+    }
+    selected = (index + items.length) % items.length;
+    items[selected].classList.add("selected");
+  }
+
+  if (input && results) {
+    input.addEventListener("input", search);
+    input.addEventListener("keydown", function (event) {
+      if (event.key === "ArrowDown") {
+        select(selected + 1);
+        event.preventDefault();
+      } else if (event.key === "ArrowUp") {
+        select(selected - 1);
+        event.preventDefault();
+      } else if (event.key === "Enter") {
+        var items = results.querySelectorAll("li a");
+        var target = items[selected >= 0 ? selected : 0];
+        if (target) {
+          window.location.href = target.href;
+        }
+      } else if (event.key === "Escape") {
+        results.hidden = true;
+      }
+    });
+    document.addEventListener("click", function (event) {
+      if (!results.contains(event.target) && event.target !== input) {
+        results.hidden = true;
+      }
+    });
+  }
+})();
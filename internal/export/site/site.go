@@ -0,0 +1,307 @@
+package site
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/helper"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+//go:embed assets/*
+var assetsFS embed.FS
+
+const INDEX_PAGE = "index.html"
+const SEARCH_DATA_FILE = "search-data.js"
+const VERSIONS_FILE = "versions.js"
+
+/*
+@description Struct to represent the options used to render the documentation site
+@author Dorian TERBAH
+@field Title string - The title of the site, usually the project name
+@field Description string - The description of the project, displayed on the home page
+@field Version string - The version of the documentation being rendered
+*/
+type Options struct {
+	Title       string
+	Description string
+	Version     string
+}
+
+/*
+@description Struct to represent a documented symbol with the data needed by the templates
+@author Dorian TERBAH
+@field Name string - The qualified name of the symbol (e.g. DocParser.ParseDocForDir)
+@field Kind string - The kind of the symbol (function, struct, interface)
+@field Package string - The package declaring the symbol
+@field URL string - The path of the symbol page, relative to the site root
+@field Item doc.DocItem - The documented item
+@field File doc.FileDoc - The file declaring the symbol
+@field Methods []*Symbol - The documented methods, for structs
+*/
+type Symbol struct {
+	Name    string
+	Kind    string
+	Package string
+	URL     string
+	Item    doc.DocItem
+	File    doc.FileDoc
+	Methods []*Symbol
+}
+
+/*
+@description Struct to represent a package with its symbols, used by the templates
+@author Dorian TERBAH
+@field Name string - The name of the package
+@field URL string - The path of the package page, relative to the site root
+@field Symbols []*Symbol - The types and functions of the package (methods are attached to their struct)
+*/
+type Package struct {
+	Name    string
+	URL     string
+	Symbols []*Symbol
+}
+
+// page is the data given to every template
+type page struct {
+	Title    string
+	Root     string
+	Options  Options
+	Packages []*Package
+	Package  *Package
+	Symbol   *Symbol
+}
+
+// searchEntry is an entry of the client-side search data
+type searchEntry struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Package     string `json:"package"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+var templateFuncs = siteFuncs()
+
+// siteFuncs adds the helpers specific to the site to the shared template functions
+func siteFuncs() template.FuncMap {
+	funcs := template.FuncMap(helper.TemplateFuncs())
+	funcs["withMethods"] = allSymbols
+	return funcs
+}
+
+/*
+@description Render the documentation of a project as a static HTML site: a home page, a page per package and a page per symbol, with the embedded assets and the client-side search data
+@param projectDoc doc.ProjectDoc - The documentation to render
+@param options Options - The rendering options
+@return (map[string][]byte, error) - The content of the site, indexed by slash-separated paths relative to the site root, and an error if a template fails
+@example Build(projectDoc, Options{Title: "zendoc", Version: "1.0"})
+@author Dorian TERBAH
+*/
+func Build(projectDoc doc.ProjectDoc, options Options) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	packages := collectPackages(projectDoc)
+
+	if err := copyAssets(files); err != nil {
+		return nil, err
+	}
+
+	searchData, err := buildSearchData(packages)
+	if err != nil {
+		return nil, err
+	}
+	files[SEARCH_DATA_FILE] = searchData
+
+	base := page{Options: options, Packages: packages}
+
+	home := base
+	home.Title = options.Title
+	if err := render(files, "index.html", INDEX_PAGE, home); err != nil {
+		return nil, err
+	}
+
+	for _, pck := range packages {
+		pckPage := base
+		pckPage.Title = "Package " + pck.Name
+		pckPage.Package = pck
+		if err := render(files, "package.html", pck.URL, pckPage); err != nil {
+			return nil, err
+		}
+
+		for _, symbol := range allSymbols(pck) {
+			symbolPage := pckPage
+			symbolPage.Title = symbol.Name
+			symbolPage.Symbol = symbol
+			if err := render(files, "symbol.html", symbol.URL, symbolPage); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return files, nil
+}
+
+/*
+@description Render the script listing the available versions of the documentation, used by the version switcher
+@param versions []string - The available versions
+@param latest string - The version the site root redirects to
+@return ([]byte, error) - The content of the script and an error if the versions cannot be serialized
+@author Dorian TERBAH
+*/
+func BuildVersions(versions []string, latest string) ([]byte, error) {
+	data, err := json.Marshal(map[string]any{
+		"versions": versions,
+		"latest":   latest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when serializing the versions: %w", err)
+	}
+
+	return []byte(fmt.Sprintf("window.ZENDOC_VERSIONS = %s;\n", data)), nil
+}
+
+/*
+@description Render the page redirecting the root of a multi-version site to a version
+@param version string - The version to redirect to
+@return []byte - The content of the redirection page
+@author Dorian TERBAH
+*/
+func BuildRedirect(version string) []byte {
+	target := template.HTMLEscapeString(path.Join(version, INDEX_PAGE))
+	return []byte(fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="0; url=%s">
+</head>
+<body><a href="%s">%s</a></body>
+</html>
+`, target, target, target))
+}
+
+// collectPackages sorts the packages and their symbols, attaching methods to their documented struct
+func collectPackages(projectDoc doc.ProjectDoc) []*Package {
+	packages := []*Package{}
+
+	for _, pckName := range helper.SortedPackages(projectDoc) {
+		pck := &Package{
+			Name: pckName,
+			URL:  path.Join("packages", pckName+".html"),
+		}
+
+		items := helper.SortedItems(projectDoc.PackageDocs[pckName])
+		types := map[string]*Symbol{}
+		for _, item := range items {
+			if _, isFunction := item.Item.(*doc.FuncDoc); !isFunction {
+				symbol := newSymbol(pckName, item)
+				types[symbol.Name] = symbol
+				pck.Symbols = append(pck.Symbols, symbol)
+			}
+		}
+
+		functions := []*Symbol{}
+		for _, item := range items {
+			fd, isFunction := item.Item.(*doc.FuncDoc)
+			if !isFunction {
+				continue
+			}
+
+			symbol := newSymbol(pckName, item)
+			if owner, ok := types[fd.Struct]; ok && fd.Struct != "" {
+				owner.Methods = append(owner.Methods, symbol)
+			} else {
+				functions = append(functions, symbol)
+			}
+		}
+		pck.Symbols = append(pck.Symbols, functions...)
+
+		packages = append(packages, pck)
+	}
+
+	return packages
+}
+
+func newSymbol(pckName string, item helper.PackageItem) *Symbol {
+	name := helper.ItemName(item.Item)
+	return &Symbol{
+		Name:    name,
+		Kind:    item.Item.GetBaseDoc().Type,
+		Package: pckName,
+		URL:     path.Join("symbols", pckName, name+".html"),
+		Item:    item.Item,
+		File:    item.File,
+	}
+}
+
+func allSymbols(pck *Package) []*Symbol {
+	symbols := []*Symbol{}
+	for _, symbol := range pck.Symbols {
+		symbols = append(symbols, symbol)
+		symbols = append(symbols, symbol.Methods...)
+	}
+	return symbols
+}
+
+// render executes a page template inside the layout and stores the result
+func render(files map[string][]byte, name, target string, data page) error {
+	tmpl, err := template.New("layout.html").Funcs(templateFuncs).ParseFS(templatesFS, "templates/layout.html", "templates/"+name)
+	if err != nil {
+		return fmt.Errorf("error when loading the template %s: %w", name, err)
+	}
+
+	data.Root = strings.Repeat("../", strings.Count(target, "/"))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("error when rendering %s: %w", target, err)
+	}
+
+	files[target] = buf.Bytes()
+	return nil
+}
+
+func copyAssets(files map[string][]byte) error {
+	return fs.WalkDir(assetsFS, "assets", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := assetsFS.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		files[filePath] = content
+		return nil
+	})
+}
+
+func buildSearchData(packages []*Package) ([]byte, error) {
+	entries := []searchEntry{}
+	for _, pck := range packages {
+		for _, symbol := range allSymbols(pck) {
+			entries = append(entries, searchEntry{
+				Name:        symbol.Name,
+				Kind:        symbol.Kind,
+				Package:     symbol.Package,
+				URL:         symbol.URL,
+				Description: symbol.Item.GetBaseDoc().Description,
+			})
+		}
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("error when serializing the search data: %w", err)
+	}
+
+	return []byte(fmt.Sprintf("window.ZENDOC_SEARCH = %s;\n", data)), nil
+}
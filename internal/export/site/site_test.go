@@ -0,0 +1,55 @@
+package site
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	files, err := Build(doctest.Sample(), Options{Title: "zendoc", Version: "1.0"})
+	assert.NoError(t, err)
+
+	for _, path := range []string{
+		INDEX_PAGE,
+		SEARCH_DATA_FILE,
+		"assets/style.css",
+		"assets/search.js",
+		"packages/parser.html",
+		"packages/system.html",
+		"symbols/parser/DocParser.html",
+		"symbols/parser/DocParser.ParseDocForDir.html",
+		"symbols/system/CommandRunner.html",
+	} {
+		assert.Contains(t, files, path)
+	}
+
+	method := string(files["symbols/parser/DocParser.ParseDocForDir.html"])
+	assert.Contains(t, method, `href="../../assets/style.css"`)
+	assert.Contains(t, method, "func (DocParser) ParseDocForDir(dirPath string)")
+	assert.Contains(t, method, "<strong>Deprecated:</strong> Use ParseFS")
+	assert.Contains(t, method, `<a href="https://github.com/a/b/blob/main/internal/parser/parser.go#L74-L123">internal/parser/parser.go:74</a>`)
+
+	structPage := string(files["symbols/parser/DocParser.html"])
+	assert.Contains(t, structPage, "The &lt;parser&gt;")
+	assert.Contains(t, structPage, `href="../../symbols/parser/DocParser.ParseDocForDir.html"`)
+
+	iface := string(files["symbols/system/CommandRunner.html"])
+	assert.Contains(t, iface, "type CommandRunner interface {\n\tExecute()\n}")
+
+	searchData := string(files[SEARCH_DATA_FILE])
+	assert.True(t, strings.HasPrefix(searchData, "window.ZENDOC_SEARCH = "))
+	assert.Contains(t, searchData, `"url":"symbols/parser/DocParser.ParseDocForDir.html"`)
+}
+
+func TestBuildVersions(t *testing.T) {
+	content, err := BuildVersions([]string{"1.0", "1.1"}, "1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "window.ZENDOC_VERSIONS = {\"latest\":\"1.1\",\"versions\":[\"1.0\",\"1.1\"]};\n", string(content))
+}
+
+func TestBuildRedirect(t *testing.T) {
+	assert.Contains(t, string(BuildRedirect("1.1")), `url=1.1/index.html`)
+}
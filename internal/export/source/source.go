@@ -0,0 +1,210 @@
+package source
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+)
+
+/*
+@description Git forge hosting the source code, used to choose the URL scheme of the source links
+@author Dorian TERBAH
+*/
+type Provider string
+
+const (
+	GITHUB    Provider = "github"
+	GITLAB    Provider = "gitlab"
+	GITEA     Provider = "gitea"
+	BITBUCKET Provider = "bitbucket"
+)
+
+// hosts used to recognize a provider when it is not set in the configuration
+var knownHosts = []struct {
+	name     string
+	provider Provider
+}{
+	{"github", GITHUB},
+	{"gitlab", GITLAB},
+	{"gitea", GITEA},
+	{"codeberg", GITEA},
+	{"forgejo", GITEA},
+	{"bitbucket", BITBUCKET},
+}
+
+/*
+@description Struct responsible for building permalinks to documented items on a git forge
+@author Dorian TERBAH
+@field Provider Provider - The git forge hosting the repository
+@field RepoURL string - The web URL of the repository, without trailing slash
+@field Ref string - The branch name or commit SHA the links point to
+@field IsCommit bool - true if Ref is a commit SHA rather than a branch name
+*/
+type LinkBuilder struct {
+	Provider Provider
+	RepoURL  string
+	Ref      string
+	IsCommit bool
+}
+
+/*
+@description Parse a provider name from the configuration
+@param name string - The name of the provider ("github", "gitlab", "gitea" or "bitbucket")
+@return (Provider, error) - The associated provider and an error if the name is unknown
+@example ParseProvider("gitlab") => GITLAB, nil
+@author Dorian TERBAH
+*/
+func ParseProvider(name string) (Provider, error) {
+	provider := Provider(strings.ToLower(strings.TrimSpace(name)))
+	switch provider {
+	case GITHUB, GITLAB, GITEA, BITBUCKET:
+		return provider, nil
+	}
+
+	return "", fmt.Errorf("unknown git provider \"%s\"", name)
+}
+
+/*
+@description Detect the git forge of a repository from its link
+@param repoURL string - The web URL of the repository
+@return (Provider, error) - The detected provider and an error if the host is not recognized
+@example DetectProvider("https://gitlab.com/group/project") => GITLAB, nil
+@author Dorian TERBAH
+*/
+func DetectProvider(repoURL string) (Provider, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid git link %s: %w", repoURL, err)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, known := range knownHosts {
+		if strings.Contains(host, known.name) {
+			return known.provider, nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to detect the git provider of %s, set \"gitProvider\" in your configuration", repoURL)
+}
+
+/*
+@description Create a link builder for a repository
+@param gitLink string - The git link of the project, either a web URL or an SSH remote
+@param providerName string - The name of the provider, or an empty string to detect it from the link
+@param ref string - The branch name or commit SHA the links point to
+@param isCommit bool - true if ref is a commit SHA
+@return (*LinkBuilder, error) - The link builder and an error if the link or the provider is invalid
+@example NewLinkBuilder("git@github.com:ZenDocLabs/zendoc.git", "", "main", false)
+@author Dorian TERBAH
+*/
+func NewLinkBuilder(gitLink, providerName, ref string, isCommit bool) (*LinkBuilder, error) {
+	repoURL := normalizeRepoURL(gitLink)
+	if repoURL == "" {
+		return nil, fmt.Errorf("no git link configured")
+	}
+
+	var provider Provider
+	var err error
+	if providerName != "" {
+		provider, err = ParseProvider(providerName)
+	} else {
+		provider, err = DetectProvider(repoURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkBuilder{
+		Provider: provider,
+		RepoURL:  repoURL,
+		Ref:      ref,
+		IsCommit: isCommit,
+	}, nil
+}
+
+// normalizeRepoURL turns SSH remotes and clone URLs into the web URL of the repository
+func normalizeRepoURL(gitLink string) string {
+	link := strings.TrimSpace(gitLink)
+	if strings.HasPrefix(link, "git@") {
+		// git@host:owner/repo.git
+		link = strings.TrimPrefix(link, "git@")
+		link = "https://" + strings.Replace(link, ":", "/", 1)
+	}
+
+	link = strings.TrimSuffix(link, "/")
+	link = strings.TrimSuffix(link, ".git")
+	return link
+}
+
+/*
+@description Build the permalink of a source range
+@param filePath string - The path of the file, relative to the repository root
+@param position doc.Position - The range to highlight
+@return string - The permalink to the range
+@example LinkBuilder{Provider: GITHUB, RepoURL: "https://github.com/a/b", Ref: "main"}.Link("main.go", pos) => "https://github.com/a/b/blob/main/main.go#L3-L5"
+@author Dorian TERBAH
+*/
+func (builder LinkBuilder) Link(filePath string, position doc.Position) string {
+	filePath = strings.TrimPrefix(filepath.ToSlash(filePath), "/")
+	start, end := position.StartLine, position.EndLine
+
+	switch builder.Provider {
+	case GITLAB:
+		return fmt.Sprintf("%s/-/blob/%s/%s%s", builder.RepoURL, builder.Ref, filePath, lineAnchor("#L%d", "#L%d-%d", start, end))
+	case GITEA:
+		refKind := "branch"
+		if builder.IsCommit {
+			refKind = "commit"
+		}
+		return fmt.Sprintf("%s/src/%s/%s/%s%s", builder.RepoURL, refKind, builder.Ref, filePath, lineAnchor("#L%d", "#L%d-L%d", start, end))
+	case BITBUCKET:
+		return fmt.Sprintf("%s/src/%s/%s%s", builder.RepoURL, builder.Ref, filePath, lineAnchor("#lines-%d", "#lines-%d:%d", start, end))
+	default:
+		return fmt.Sprintf("%s/blob/%s/%s%s", builder.RepoURL, builder.Ref, filePath, lineAnchor("#L%d", "#L%d-L%d", start, end))
+	}
+}
+
+// lineAnchor formats the fragment highlighting a single line or a range of lines
+func lineAnchor(single, multi string, start, end int) string {
+	if start <= 0 {
+		return ""
+	}
+	if end <= start {
+		return fmt.Sprintf(single, start)
+	}
+	return fmt.Sprintf(multi, start, end)
+}
+
+/*
+@description Set the source link of every documented item of a project
+@param projectDoc *doc.ProjectDoc - The documentation to annotate
+@author Dorian TERBAH
+*/
+func (builder LinkBuilder) Annotate(projectDoc *doc.ProjectDoc) {
+	for _, files := range projectDoc.PackageDocs {
+		for _, fileDoc := range files {
+			builder.AnnotateFile(fileDoc)
+		}
+	}
+}
+
+/*
+@description Set the source link of every documented item of a file, for the documentations streamed file by file
+@param fileDoc doc.FileDoc - The documentation of the file, whose items are annotated in place
+@author Dorian TERBAH
+*/
+func (builder LinkBuilder) AnnotateFile(fileDoc doc.FileDoc) {
+	for _, item := range fileDoc.Docs {
+		baseDoc := item.GetBaseDoc()
+		baseDoc.SourceLink = builder.Link(fileDoc.Path, baseDoc.Position)
+
+		if iface, ok := item.(*doc.InterfaceDoc); ok {
+			for i := range iface.Methods {
+				iface.Methods[i].SourceLink = builder.Link(fileDoc.Path, iface.Methods[i].Position)
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectProvider(t *testing.T) {
+	tests := []struct {
+		link     string
+		expected Provider
+	}{
+		{"https://github.com/ZenDocLabs/zendoc", GITHUB},
+		{"https://gitlab.example.com/group/project", GITLAB},
+		{"https://codeberg.org/owner/repo", GITEA},
+		{"https://gitea.company.io/owner/repo", GITEA},
+		{"https://bitbucket.org/workspace/repo", BITBUCKET},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			provider, err := DetectProvider(tt.link)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, provider)
+		})
+	}
+
+	_, err := DetectProvider("https://git.company.io/owner/repo")
+	assert.Error(t, err)
+}
+
+func TestNewLinkBuilder_NormalizeLink(t *testing.T) {
+	builder, err := NewLinkBuilder("git@github.com:ZenDocLabs/zendoc.git", "", "main", false)
+	assert.NoError(t, err)
+	assert.Equal(t, GITHUB, builder.Provider)
+	assert.Equal(t, "https://github.com/ZenDocLabs/zendoc", builder.RepoURL)
+
+	builder, err = NewLinkBuilder("https://git.company.io/owner/repo/", "GitLab", "main", false)
+	assert.NoError(t, err)
+	assert.Equal(t, GITLAB, builder.Provider)
+	assert.Equal(t, "https://git.company.io/owner/repo", builder.RepoURL)
+
+	_, err = NewLinkBuilder("https://git.company.io/owner/repo", "svn", "main", false)
+	assert.Error(t, err)
+
+	_, err = NewLinkBuilder("", "", "main", false)
+	assert.Error(t, err)
+}
+
+func TestLinkBuilder_Link(t *testing.T) {
+	position := doc.Position{StartLine: 10, StartColumn: 1, EndLine: 20, EndColumn: 2}
+
+	tests := []struct {
+		name     string
+		builder  LinkBuilder
+		expected string
+	}{
+		{"GitHub", LinkBuilder{Provider: GITHUB, RepoURL: "https://github.com/a/b", Ref: "main"}, "https://github.com/a/b/blob/main/internal/parser.go#L10-L20"},
+		{"GitLab", LinkBuilder{Provider: GITLAB, RepoURL: "https://gitlab.com/a/b", Ref: "main"}, "https://gitlab.com/a/b/-/blob/main/internal/parser.go#L10-20"},
+		{"Gitea branch", LinkBuilder{Provider: GITEA, RepoURL: "https://codeberg.org/a/b", Ref: "main"}, "https://codeberg.org/a/b/src/branch/main/internal/parser.go#L10-L20"},
+		{"Gitea commit", LinkBuilder{Provider: GITEA, RepoURL: "https://codeberg.org/a/b", Ref: "abc123", IsCommit: true}, "https://codeberg.org/a/b/src/commit/abc123/internal/parser.go#L10-L20"},
+		{"Bitbucket", LinkBuilder{Provider: BITBUCKET, RepoURL: "https://bitbucket.org/a/b", Ref: "main"}, "https://bitbucket.org/a/b/src/main/internal/parser.go#lines-10:20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.builder.Link("internal/parser.go", position))
+		})
+	}
+
+	builder := LinkBuilder{Provider: GITHUB, RepoURL: "https://github.com/a/b", Ref: "main"}
+	assert.Equal(t, "https://github.com/a/b/blob/main/main.go#L3", builder.Link("main.go", doc.Position{StartLine: 3, EndLine: 3}))
+	assert.Equal(t, "https://github.com/a/b/blob/main/main.go", builder.Link("main.go", doc.Position{}))
+}
+
+func TestLinkBuilder_Annotate(t *testing.T) {
+	builder := LinkBuilder{Provider: GITHUB, RepoURL: "https://github.com/a/b", Ref: "main"}
+	projectDoc := doc.ProjectDoc{
+		PackageDocs: map[string][]doc.FileDoc{
+			"system": {
+				{
+					FileName: "cmd.go",
+					Path:     "internal/system/cmd.go",
+					Docs: []doc.DocItem{
+						&doc.InterfaceDoc{
+							BaseDoc: doc.BaseDoc{Name: "CommandRunner", Type: "interface", Position: doc.Position{StartLine: 5, EndLine: 18}},
+							Methods: []doc.FuncDoc{
+								{BaseDoc: doc.BaseDoc{Name: "Execute", Position: doc.Position{StartLine: 17, EndLine: 17}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	builder.Annotate(&projectDoc)
+
+	iface := projectDoc.PackageDocs["system"][0].Docs[0].(*doc.InterfaceDoc)
+	assert.Equal(t, "https://github.com/a/b/blob/main/internal/system/cmd.go#L5-L18", iface.SourceLink)
+	assert.Equal(t, "https://github.com/a/b/blob/main/internal/system/cmd.go#L17", iface.Methods[0].SourceLink)
+}
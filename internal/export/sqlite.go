@@ -0,0 +1,299 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/app"
+	"github.com/dterbah/zendoc/internal/export/helper"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
+	_ "modernc.org/sqlite"
+)
+
+const SQLITE_EXPORT_FILE = "zendoc.sqlite"
+
+// Version of the schema of sqlite.sql, stored in the user_version of the database. It changes when a table or a column changes.
+const SQLITE_SCHEMA_VERSION = 1
+
+//go:embed sqlite.sql
+var sqliteSchema string
+
+/*
+@description Struct that implements the DocExporter interface and exports the documentation into a SQLite database, with a table for the packages, the files, the symbols, their params, fields, methods and tags. Each version is stored next to the versions exported before it, and exporting a version again replaces it.
+@author Dorian TERBAH
+@field Output string - The path of the database, created when it does not exist
+@field Version string - The version of the documentation to export
+@field CmdRunner system.CommandRunner - The runner used to record the git revision of the version, may be nil
+@field Revision string - The git revision the documentation is generated from, HEAD when empty
+*/
+type SQLiteExporter struct {
+	Output    string
+	Version   string
+	CmdRunner system.CommandRunner
+	Revision  string
+}
+
+/*
+@description Export the project documentation as a version of the database. The version is written in a single SQL transaction, so a failed or cancelled export leaves the database as it was.
+@param ctx context.Context - The context of the export
+@param projectDoc doc.ProjectDoc - The documentation to export
+@return error - An error if the database cannot be opened, has a newer schema or cannot be written
+@example SQLiteExporter{Output: "doc/zendoc.sqlite", Version: "1.0.0"}.Export(ctx, projectDoc)
+@author Dorian TERBAH
+*/
+func (sqliteExport SQLiteExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	entry := app.NewVersionEntry(ctx, sqliteExport.Version, sqliteExport.Revision, projectDoc, sqliteExport.CmdRunner)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(sqliteExport.Output), os.ModePerm); err != nil {
+		return fmt.Errorf("error when creating the folder of %s: %w", sqliteExport.Output, err)
+	}
+
+	db, err := sql.Open("sqlite", sqliteExport.Output+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		return fmt.Errorf("error when opening %s: %w", sqliteExport.Output, err)
+	}
+	defer db.Close()
+
+	if err := migrateSQLiteSchema(ctx, db); err != nil {
+		return fmt.Errorf("error when preparing %s: %w", sqliteExport.Output, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error when writing %s: %w", sqliteExport.Output, err)
+	}
+	defer tx.Rollback()
+
+	if err := writeSQLiteVersion(ctx, tx, entry, projectDoc); err != nil {
+		return fmt.Errorf("error when writing the version %s in %s: %w", sqliteExport.Version, sqliteExport.Output, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error when writing %s: %w", sqliteExport.Output, err)
+	}
+
+	color.Green("Documentation v%s saved in %s!", sqliteExport.Version, sqliteExport.Output)
+	return nil
+}
+
+/*
+@description Give the files written by the export, the database and its rollback journal
+@return []string - The paths of the files
+@example SQLiteExporter{Output: "doc.sqlite"}.OutputFiles() => ["doc.sqlite", "doc.sqlite-journal"]
+@author Dorian TERBAH
+*/
+func (sqliteExport SQLiteExporter) OutputFiles() []string {
+	return []string{sqliteExport.Output, sqliteExport.Output + "-journal"}
+}
+
+// migrateSQLiteSchema creates the tables of a new database, and refuses the databases written by a newer zendoc
+func migrateSQLiteSchema(ctx context.Context, db *sql.DB) error {
+	var schemaVersion int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&schemaVersion); err != nil {
+		return err
+	}
+
+	if schemaVersion > SQLITE_SCHEMA_VERSION {
+		return fmt.Errorf("the database has the schema version %d, but this zendoc only supports the version %d", schemaVersion, SQLITE_SCHEMA_VERSION)
+	}
+
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		return err
+	}
+	_, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", SQLITE_SCHEMA_VERSION))
+	return err
+}
+
+// sqliteWriter holds the statements inserting the rows of a version
+type sqliteWriter struct {
+	packages *sql.Stmt
+	files    *sql.Stmt
+	symbols  *sql.Stmt
+	params   *sql.Stmt
+	fields   *sql.Stmt
+	methods  *sql.Stmt
+	tags     *sql.Stmt
+}
+
+// writeSQLiteVersion replaces the rows of a version by the given documentation
+func writeSQLiteVersion(ctx context.Context, tx *sql.Tx, entry app.VersionEntry, projectDoc doc.ProjectDoc) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM versions WHERE name = ?", entry.Version); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO versions (name, generated_at, commit_sha, tag, zendoc_version, schema_version) VALUES (?, ?, ?, ?, ?, ?)",
+		entry.Version, entry.GeneratedAt, entry.Commit, entry.Tag, entry.ZendocVersion, entry.SchemaVersion)
+	if err != nil {
+		return err
+	}
+	versionID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	writer := sqliteWriter{}
+	statements := []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		{&writer.packages, "INSERT INTO packages (version_id, name) VALUES (?, ?)"},
+		{&writer.files, "INSERT INTO files (package_id, name, path) VALUES (?, ?, ?)"},
+		{&writer.symbols, `INSERT INTO symbols (file_id, name, qualified_name, kind, receiver, description, signature, source_link, return_type, return_description, start_line, start_column, end_line, end_column)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`},
+		{&writer.params, "INSERT INTO params (symbol_id, position, name, type, description) VALUES (?, ?, ?, ?, ?)"},
+		{&writer.fields, "INSERT INTO fields (symbol_id, position, name, type, description) VALUES (?, ?, ?, ?, ?)"},
+		{&writer.methods, "INSERT INTO methods (owner_id, method_id) VALUES (?, ?)"},
+		{&writer.tags, "INSERT INTO tags (symbol_id, name, value) VALUES (?, ?, ?)"},
+	}
+	for _, statement := range statements {
+		stmt, err := tx.PrepareContext(ctx, statement.query)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		*statement.stmt = stmt
+	}
+
+	for _, pckName := range helper.SortedPackages(projectDoc) {
+		if err := writer.writePackage(ctx, versionID, pckName, projectDoc.PackageDocs[pckName]); err != nil {
+			return err
+		}
+	}
+
+	// the methods of a struct are declared anywhere in its package, so they are linked once every file is written
+	_, err = tx.ExecContext(ctx, `INSERT OR IGNORE INTO methods (owner_id, method_id)
+		SELECT owner.id, method.id
+		FROM symbols method
+		JOIN files method_file ON method_file.id = method.file_id
+		JOIN packages ON packages.id = method_file.package_id
+		JOIN files owner_file ON owner_file.package_id = packages.id
+		JOIN symbols owner ON owner.file_id = owner_file.id AND owner.name = method.receiver AND owner.kind = ?
+		WHERE packages.version_id = ? AND method.kind = ?`,
+		doc.STRUCT_TYPE, versionID, doc.FUNCTION_TYPE)
+	return err
+}
+
+func (writer sqliteWriter) writePackage(ctx context.Context, versionID int64, pckName string, files []doc.FileDoc) error {
+	packageID, err := insertRow(ctx, writer.packages, versionID, pckName)
+	if err != nil {
+		return err
+	}
+
+	for _, fileDoc := range files {
+		fileID, err := insertRow(ctx, writer.files, packageID, fileDoc.FileName, fileDoc.Path)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range fileDoc.Docs {
+			symbolID, err := writer.writeSymbol(ctx, fileID, item, "")
+			if err != nil {
+				return err
+			}
+
+			iface, ok := item.(*doc.InterfaceDoc)
+			if !ok {
+				continue
+			}
+			for i := range iface.Methods {
+				methodID, err := writer.writeSymbol(ctx, fileID, &iface.Methods[i], iface.Name)
+				if err != nil {
+					return err
+				}
+				if _, err := writer.methods.ExecContext(ctx, symbolID, methodID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeSymbol inserts a symbol with its params, fields and tags. The receiver is the struct of a method, or the interface of an interface method.
+func (writer sqliteWriter) writeSymbol(ctx context.Context, fileID int64, item doc.DocItem, receiver string) (int64, error) {
+	baseDoc := item.GetBaseDoc()
+
+	var params, fields []doc.Param
+	var returnDoc *doc.Return
+	example := ""
+	switch symbol := item.(type) {
+	case *doc.FuncDoc:
+		params, returnDoc, example = symbol.Params, symbol.Return, symbol.Example
+		if symbol.Struct != "" {
+			receiver = symbol.Struct
+		}
+	case *doc.StructDoc:
+		fields = symbol.Fields
+	}
+
+	qualifiedName := baseDoc.Name
+	if receiver != "" {
+		qualifiedName = receiver + "." + baseDoc.Name
+	}
+
+	var returnType, returnDescription any
+	if returnDoc != nil {
+		returnType, returnDescription = returnDoc.Type, returnDoc.Description
+	}
+
+	symbolID, err := insertRow(ctx, writer.symbols, fileID, baseDoc.Name, qualifiedName, baseDoc.Type, nullString(receiver),
+		baseDoc.Description, baseDoc.Signature, baseDoc.SourceLink, returnType, returnDescription,
+		baseDoc.Position.StartLine, baseDoc.Position.StartColumn, baseDoc.Position.EndLine, baseDoc.Position.EndColumn)
+	if err != nil {
+		return 0, err
+	}
+
+	for position, param := range params {
+		if _, err := writer.params.ExecContext(ctx, symbolID, position, param.Name, param.Type, param.Description); err != nil {
+			return 0, err
+		}
+	}
+	for position, field := range fields {
+		if _, err := writer.fields.ExecContext(ctx, symbolID, position, field.Name, field.Type, field.Description); err != nil {
+			return 0, err
+		}
+	}
+
+	tags := []struct{ name, value string }{
+		{"author", baseDoc.Author},
+		{"deprecated", baseDoc.Deprecated},
+		{"example", example},
+	}
+	for _, tag := range tags {
+		if tag.value == "" {
+			continue
+		}
+		if _, err := writer.tags.ExecContext(ctx, symbolID, tag.name, tag.value); err != nil {
+			return 0, err
+		}
+	}
+
+	return symbolID, nil
+}
+
+func insertRow(ctx context.Context, stmt *sql.Stmt, args ...any) (int64, error) {
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func nullString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+-- Schema of the database written by the sqlite output, documented in documentation/cli.md.
+-- Its version is stored in PRAGMA user_version, and the tables of a version are deleted with it.
+
+CREATE TABLE IF NOT EXISTS versions (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    generated_at TEXT NOT NULL,
+    commit_sha TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    zendoc_version TEXT NOT NULL,
+    schema_version TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS packages (
+    id INTEGER PRIMARY KEY,
+    version_id INTEGER NOT NULL REFERENCES versions (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    UNIQUE (version_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS files (
+    id INTEGER PRIMARY KEY,
+    package_id INTEGER NOT NULL REFERENCES packages (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    path TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS symbols (
+    id INTEGER PRIMARY KEY,
+    file_id INTEGER NOT NULL REFERENCES files (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    qualified_name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    receiver TEXT,
+    description TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    source_link TEXT NOT NULL,
+    return_type TEXT,
+    return_description TEXT,
+    start_line INTEGER NOT NULL,
+    start_column INTEGER NOT NULL,
+    end_line INTEGER NOT NULL,
+    end_column INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS params (
+    symbol_id INTEGER NOT NULL REFERENCES symbols (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    description TEXT NOT NULL,
+    PRIMARY KEY (symbol_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS fields (
+    symbol_id INTEGER NOT NULL REFERENCES symbols (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    description TEXT NOT NULL,
+    PRIMARY KEY (symbol_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS methods (
+    owner_id INTEGER NOT NULL REFERENCES symbols (id) ON DELETE CASCADE,
+    method_id INTEGER NOT NULL REFERENCES symbols (id) ON DELETE CASCADE,
+    PRIMARY KEY (owner_id, method_id)
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    symbol_id INTEGER NOT NULL REFERENCES symbols (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (symbol_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS files_package ON files (package_id);
+CREATE INDEX IF NOT EXISTS symbols_file ON symbols (file_id);
+CREATE INDEX IF NOT EXISTS symbols_kind ON symbols (kind);
+CREATE INDEX IF NOT EXISTS methods_method ON methods (method_id);
+CREATE INDEX IF NOT EXISTS tags_name ON tags (name);
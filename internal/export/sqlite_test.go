@@ -0,0 +1,75 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func queryInt(t *testing.T, db *sql.DB, query string, args ...any) int {
+	var value int
+	assert.NoError(t, db.QueryRow(query, args...).Scan(&value))
+	return value
+}
+
+func TestSQLiteExporter_Export(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "doc", SQLITE_EXPORT_FILE)
+	exporter := SQLiteExporter{Output: output, Version: "1.0.0"}
+
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+	exporter.Version = "1.1.0"
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+	// exporting a version again replaces it
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	db, err := sql.Open("sqlite", output)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.Equal(t, 2, queryInt(t, db, "SELECT COUNT(*) FROM versions"))
+	assert.Equal(t, 18, queryInt(t, db, "SELECT COUNT(*) FROM symbols"))
+	assert.Equal(t, SQLITE_SCHEMA_VERSION, queryInt(t, db, "PRAGMA user_version"))
+
+	deprecated := queryInt(t, db, `SELECT COUNT(*) FROM symbols
+		JOIN files ON files.id = symbols.file_id
+		JOIN packages ON packages.id = files.package_id
+		JOIN versions ON versions.id = packages.version_id
+		JOIN tags ON tags.symbol_id = symbols.id AND tags.name = 'deprecated'
+		WHERE versions.name = ? AND packages.name = ? AND symbols.kind = 'function'`, "1.1.0", "parser")
+	assert.Equal(t, 2, deprecated)
+
+	// the method is linked to its struct, and keeps its params
+	methods := queryInt(t, db, `SELECT COUNT(*) FROM methods
+		JOIN symbols owner ON owner.id = methods.owner_id
+		JOIN symbols method ON method.id = methods.method_id
+		WHERE owner.name = 'DocParser' AND method.qualified_name = 'DocParser.ParseDocForDir'`)
+	assert.Equal(t, 2, methods)
+	assert.Equal(t, 2, queryInt(t, db, "SELECT COUNT(*) FROM params WHERE name = 'dirPath'"))
+	assert.Equal(t, 2, queryInt(t, db, "SELECT COUNT(*) FROM fields WHERE name = 'FileValidators'"))
+}
+
+func TestSQLiteExporter_Export_Errors(t *testing.T) {
+	output := filepath.Join(t.TempDir(), SQLITE_EXPORT_FILE)
+	exporter := SQLiteExporter{Output: output, Version: "1.0.0"}
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	exporter.Version = "1.1.0"
+	assert.ErrorIs(t, exporter.Export(ctx, doctest.Sample()), context.Canceled)
+
+	db, err := sql.Open("sqlite", output)
+	assert.NoError(t, err)
+	defer db.Close()
+	assert.Equal(t, 1, queryInt(t, db, "SELECT COUNT(*) FROM versions"))
+
+	// a database written by a newer zendoc is left untouched
+	_, err = db.Exec("PRAGMA user_version = 99")
+	assert.NoError(t, err)
+	assert.ErrorContains(t, exporter.Export(context.Background(), doctest.Sample()), "schema version 99")
+	assert.Equal(t, 1, queryInt(t, db, "SELECT COUNT(*) FROM versions"))
+}
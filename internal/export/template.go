@@ -0,0 +1,263 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/helper"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/fatih/color"
+)
+
+const TEMPLATE_DIR = "template"
+const TEXT_TEMPLATE_ENGINE = "text"
+const HTML_TEMPLATE_ENGINE = "html"
+
+// placeholder replaced by the package name, to render a template once per package
+const TEMPLATE_PACKAGE_PLACEHOLDER = "__package__"
+
+var templateExtensions = []string{".tmpl", ".gotmpl"}
+
+/*
+@description Struct to represent the data given to the user templates
+@author Dorian TERBAH
+@field Name string - The name of the project
+@field Description string - The description of the project
+@field Version string - The version of the documentation
+@field Doc doc.ProjectDoc - The whole documentation of the project
+@field Package string - The name of the rendered package, for templates rendered once per package
+@field Files []doc.FileDoc - The documented files of the rendered package, for templates rendered once per package
+*/
+type TemplateData struct {
+	Name        string
+	Description string
+	Version     string
+	Doc         doc.ProjectDoc
+	Package     string
+	Files       []doc.FileDoc
+}
+
+/*
+@description Struct that implements the DocExporter interface and renders user-supplied Go templates against the documentation. Files ending with .tmpl or .gotmpl are executed and written without this extension, files whose name starts with "_" are partials shared by the other templates, and other files are copied as is. A "__package__" segment in a file path renders the template once per package.
+@author Dorian TERBAH
+@field Templates fs.FS - The directory containing the templates
+@field OutputDir string - The directory where the rendered files are written
+@field Engine string - Either "text" (text/template) or "html" (html/template, escaping the output)
+@field AppName string - The name of the project
+@field Description string - The description of the project
+@field Version string - The version of the documentation
+@field FileSystem system.FileSystem - The file system used to write the rendered files
+*/
+type TemplateExporter struct {
+	DocExporter
+	Templates   fs.FS
+	OutputDir   string
+	Engine      string
+	AppName     string
+	Description string
+	Version     string
+	FileSystem  system.FileSystem
+}
+
+// templateSet is the part shared by text/template and html/template
+type templateSet interface {
+	ExecuteTemplate(writer io.Writer, name string, data any) error
+}
+
+/*
+@description Render the user templates against the project documentation
+@param ctx context.Context - The context of the export
+@param projectDoc doc.ProjectDoc - The documentation to export
+@return error - An error if a template is invalid or fails, or if a file cannot be written
+@example TemplateExporter{Templates: os.DirFS("templates"), OutputDir: "doc/template", FileSystem: system.OSFileSystem{}}.Export(ctx, projectDoc)
+@author Dorian TERBAH
+*/
+func (templateExport TemplateExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	templates, partials, assets, err := templateExport.listFiles()
+	if err != nil {
+		return err
+	}
+	if len(templates) == 0 {
+		return fmt.Errorf("no template found, template files must end with %s", strings.Join(templateExtensions, " or "))
+	}
+
+	set, err := templateExport.parse(append(templates, partials...))
+	if err != nil {
+		return err
+	}
+
+	data := TemplateData{
+		Name:        templateExport.AppName,
+		Description: templateExport.Description,
+		Version:     templateExport.Version,
+		Doc:         projectDoc,
+	}
+
+	// every file is rendered before writing anything, so that a failed or cancelled export leaves the output untouched
+	targets := []string{}
+	contents := map[string][]byte{}
+	for _, name := range templates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		target := strings.TrimSuffix(name, path.Ext(name))
+
+		if !strings.Contains(target, TEMPLATE_PACKAGE_PLACEHOLDER) {
+			content, err := templateExport.render(set, name, data)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, target)
+			contents[target] = content
+			continue
+		}
+
+		for _, pckName := range helper.SortedPackages(projectDoc) {
+			pckData := data
+			pckData.Package = pckName
+			pckData.Files = projectDoc.PackageDocs[pckName]
+
+			pckTarget := strings.ReplaceAll(target, TEMPLATE_PACKAGE_PLACEHOLDER, pckName)
+			content, err := templateExport.render(set, name, pckData)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, pckTarget)
+			contents[pckTarget] = content
+		}
+	}
+
+	for _, name := range assets {
+		content, err := fs.ReadFile(templateExport.Templates, name)
+		if err != nil {
+			return fmt.Errorf("error when reading %s: %w", name, err)
+		}
+		targets = append(targets, name)
+		contents[name] = content
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	transaction := system.NewTransaction(templateExport.FileSystem)
+	defer transaction.Rollback()
+
+	for _, target := range targets {
+		if err := templateExport.writeFile(transaction, target, contents[target]); err != nil {
+			return err
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return fmt.Errorf("error when saving the rendered templates: %w", err)
+	}
+
+	color.Green("Templates rendered in %s!", templateExport.OutputDir)
+	return nil
+}
+
+// listFiles sorts the files of the template directory into templates, partials and assets
+func (templateExport TemplateExporter) listFiles() (templates, partials, assets []string, err error) {
+	err = fs.WalkDir(templateExport.Templates, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		isTemplate := false
+		for _, extension := range templateExtensions {
+			if strings.HasSuffix(name, extension) {
+				isTemplate = true
+			}
+		}
+
+		baseName := path.Base(name)
+		isPartial := strings.HasPrefix(baseName, "_") && !strings.HasPrefix(baseName, TEMPLATE_PACKAGE_PLACEHOLDER)
+
+		switch {
+		case isTemplate && isPartial:
+			partials = append(partials, name)
+		case isTemplate:
+			templates = append(templates, name)
+		default:
+			assets = append(assets, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error when listing the templates: %w", err)
+	}
+
+	sort.Strings(templates)
+	return templates, partials, assets, nil
+}
+
+// parse loads every template in a single set, so that they can include each other by their path
+func (templateExport TemplateExporter) parse(names []string) (templateSet, error) {
+	funcs := helper.TemplateFuncs()
+
+	var textSet *texttemplate.Template
+	var htmlSet *htmltemplate.Template
+	switch templateExport.Engine {
+	case "", TEXT_TEMPLATE_ENGINE:
+		textSet = texttemplate.New("").Funcs(funcs).Option("missingkey=error")
+	case HTML_TEMPLATE_ENGINE:
+		htmlSet = htmltemplate.New("").Funcs(funcs).Option("missingkey=error")
+	default:
+		return nil, fmt.Errorf("unknown template engine \"%s\", expected \"%s\" or \"%s\"", templateExport.Engine, TEXT_TEMPLATE_ENGINE, HTML_TEMPLATE_ENGINE)
+	}
+
+	for _, name := range names {
+		content, err := fs.ReadFile(templateExport.Templates, name)
+		if err != nil {
+			return nil, fmt.Errorf("error when reading the template %s: %w", name, err)
+		}
+
+		if textSet != nil {
+			_, err = textSet.New(name).Parse(string(content))
+		} else {
+			_, err = htmlSet.New(name).Parse(string(content))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error when parsing the template %s: %w", name, err)
+		}
+	}
+
+	if textSet != nil {
+		return textSet, nil
+	}
+	return htmlSet, nil
+}
+
+func (templateExport TemplateExporter) render(set templateSet, name string, data TemplateData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := set.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, fmt.Errorf("error when rendering the template %s: %w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (templateExport TemplateExporter) writeFile(transaction *system.Transaction, name string, content []byte) error {
+	target := filepath.Join(templateExport.OutputDir, filepath.FromSlash(name))
+	if err := transaction.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return fmt.Errorf("error when creating the folder of %s: %w", target, err)
+	}
+	if err := transaction.WriteFile(target, content, 0644); err != nil {
+		return fmt.Errorf("error when saving %s: %w", target, err)
+	}
+
+	return nil
+}
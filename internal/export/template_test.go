@@ -0,0 +1,77 @@
+package export
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateExporter_TextEngine(t *testing.T) {
+	fileSystem := system.NewMemoryFileSystem()
+	exporter := TemplateExporter{
+		Templates: fstest.MapFS{
+			"index.md.tmpl":                {Data: []byte(`# {{.Name}} v{{.Version}}{{range packages .Doc}}{{template "_link.tmpl" .}}{{end}}`)},
+			"_link.tmpl":                   {Data: []byte("\n- [{{.}}](packages/{{.}}.md)")},
+			"packages/__package__.md.tmpl": {Data: []byte(`{{range items .Files}}{{itemName .Item}}: {{signature .Item}} #{{anchor (itemName .Item)}}{{"\n"}}{{end}}`)},
+			"style.css":                    {Data: []byte("body {}")},
+		},
+		OutputDir:  "out",
+		AppName:    "zendoc",
+		Version:    "1.0",
+		FileSystem: fileSystem,
+	}
+
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	assert.Equal(t, "# zendoc v1.0\n- [export](packages/export.md)\n- [parser](packages/parser.md)\n- [system](packages/system.md)", readMemoryFile(t, fileSystem, "out/index.md"))
+	assert.Equal(t, "DocParser: type DocParser struct {\n\tFileValidators []DocParserFileValidator\n} #docparser\n"+
+		"DocParser.ParseDocForDir: func (DocParser) ParseDocForDir(dirPath string) (*doc.ProjectDoc, error) #docparserparsedocfordir\n"+
+		"getPackageName: func getPackageName() #getpackagename\n",
+		readMemoryFile(t, fileSystem, "out/packages/parser.md"))
+	assert.Equal(t, "body {}", readMemoryFile(t, fileSystem, "out/style.css"))
+	assert.Equal(t, []string{"out/index.md", "out/packages/export.md", "out/packages/parser.md", "out/packages/system.md", "out/style.css"}, writtenFiles(t, fileSystem))
+}
+
+func TestTemplateExporter_HTMLEngine(t *testing.T) {
+	fileSystem := system.NewMemoryFileSystem()
+	exporter := TemplateExporter{
+		Templates: fstest.MapFS{
+			"index.html.tmpl": {Data: []byte(`<h1>{{.Name}}</h1>{{markdown .Description}}`)},
+		},
+		OutputDir:   "out",
+		Engine:      HTML_TEMPLATE_ENGINE,
+		AppName:     "<zendoc>",
+		Description: "A **doc** generator",
+		FileSystem:  fileSystem,
+	}
+
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+	assert.Equal(t, "<h1>&lt;zendoc&gt;</h1><p>A <strong>doc</strong> generator</p>\n", readMemoryFile(t, fileSystem, "out/index.html"))
+}
+
+func TestTemplateExporter_Errors(t *testing.T) {
+	exporter := TemplateExporter{
+		Templates:  fstest.MapFS{"index.md.tmpl": {Data: []byte(`{{.Name}}`)}},
+		OutputDir:  "out",
+		Engine:     "jinja",
+		FileSystem: system.NewMemoryFileSystem(),
+	}
+	assert.Error(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	exporter.Engine = ""
+	exporter.Templates = fstest.MapFS{"index.md.tmpl": {Data: []byte(`{{.Unknown}}`)}}
+	assert.Error(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	exporter.Templates = fstest.MapFS{"README.md": {Data: []byte(`static`)}}
+	assert.Error(t, exporter.Export(context.Background(), doctest.Sample()))
+}
+
+func readMemoryFile(t *testing.T, fileSystem *system.MemoryFileSystem, name string) string {
+	content, err := fileSystem.ReadFile(name)
+	assert.NoError(t, err)
+	return string(content)
+}
@@ -1,35 +1,60 @@
 package export
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dterbah/zendoc/internal/parser"
+	"github.com/dterbah/zendoc/internal/system"
 	"github.com/fatih/color"
 	"github.com/fsnotify/fsnotify"
 )
 
+/*
+@description Struct watching the Go files of a project to export its documentation again after each change
+@author Dorian TERBAH
+@field Exporter DocExporter - The exporter called after each change
+@field IgnoredFiles []string - The files written by the exporter outside of the documentation folder, whose changes are ignored
+*/
 type FileWatcher struct {
-	Exporter DocExporter
+	Exporter     DocExporter
+	IgnoredFiles []string
 }
 
-func shouldIgnore(path, docPath string) bool {
+// shouldIgnore tells if a change comes from the export itself: the documentation folder, the ignored files or the temporary files of a transaction
+func (watcher FileWatcher) shouldIgnore(path, docPath string) bool {
 	absPath, _ := filepath.Abs(path)
 	absDocPath, _ := filepath.Abs(docPath)
 
-	if strings.HasPrefix(absPath, absDocPath) {
+	if absPath == absDocPath || strings.HasPrefix(absPath, absDocPath+string(filepath.Separator)) {
 		return true
 	}
-	if filepath.Base(absPath) == "doc.json" {
+	for _, ignoredFile := range watcher.IgnoredFiles {
+		if absIgnoredFile, _ := filepath.Abs(ignoredFile); absPath == absIgnoredFile {
+			return true
+		}
+	}
+	if system.IsTransactionFile(absPath) {
 		return true
 	}
 	return false
 }
 
-func (watcher FileWatcher) WatchDir(docParser parser.DocParser, dirName, docPath string) error {
+/*
+@description Export the documentation each time a Go file of the directory changes, until the context is done. An export in progress is finished or cancelled before returning, so no output is written afterwards.
+@param ctx context.Context - The context of the watch, which stops it when done
+@param docParser parser.DocParser - The parser of the project
+@param dirName string - The directory of the project
+@param docPath string - The directory of the documentation, whose changes are ignored
+@return error - An error if the watch fails, or nil once the context is done
+@author Dorian TERBAH
+*/
+func (watcher FileWatcher) WatchDir(ctx context.Context, docParser parser.DocParser, dirName, docPath string) error {
 	color.Green("Mode watched activated !")
 
 	w, err := fsnotify.NewWatcher()
@@ -42,7 +67,7 @@ func (watcher FileWatcher) WatchDir(docParser parser.DocParser, dirName, docPath
 		if err != nil {
 			return err
 		}
-		if info.IsDir() && !shouldIgnore(path, docPath) {
+		if info.IsDir() && !watcher.shouldIgnore(path, docPath) {
 			containsGoFile := false
 
 			entries, err := os.ReadDir(path)
@@ -72,32 +97,72 @@ func (watcher FileWatcher) WatchDir(docParser parser.DocParser, dirName, docPath
 		return err
 	}
 
-	done := make(chan bool)
 	errChan := make(chan error)
+	stop := make(chan struct{})
+	report := func(err error) {
+		select {
+		case errChan <- err:
+		case <-stop:
+		}
+	}
 
 	var debounceTimer *time.Timer
 	var debounceDelay = 500 * time.Millisecond
 	var debounceChan = make(chan struct{}, 1)
+	// held during an export, so that returning waits for the export in progress
+	var exporting sync.Mutex
+	defer func() {
+		exporting.Lock()
+		defer exporting.Unlock()
+		if debounceTimer != nil {
+			debounceTimer.Stop()
+		}
+	}()
+	// unblocks the export reporting an error, before waiting for it
+	defer close(stop)
 
 	go func() {
-		for range debounceChan {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-debounceChan:
+			}
+
+			exporting.Lock()
+			// the timer is never armed again once the watch is stopped
+			if isStopped(stop) {
+				exporting.Unlock()
+				return
+			}
 			if debounceTimer != nil {
 				debounceTimer.Stop()
 			}
 			debounceTimer = time.AfterFunc(debounceDelay, func() {
+				exporting.Lock()
+				defer exporting.Unlock()
+				if ctx.Err() != nil || isStopped(stop) {
+					return
+				}
+
 				color.Green("📝 Debounced export triggered")
 
-				doc, err := docParser.ParseDocForDir(dirName, "")
+				doc, err := docParser.ParseDocForDir(ctx, dirName, "")
+				if ctx.Err() != nil {
+					return
+				}
 				if err != nil {
-					errChan <- fmt.Errorf("error during parsing: %w", err)
+					// a file being edited may not compile yet, the next change exports again
+					color.Red("error during parsing: %s", err)
 					return
 				}
 
-				err = watcher.Exporter.Export(*doc)
+				err = watcher.Exporter.Export(ctx, *doc)
 				if err != nil {
-					errChan <- fmt.Errorf("error during export: %w", err)
+					report(fmt.Errorf("error during export: %w", err))
 				}
 			})
+			exporting.Unlock()
 		}
 	}()
 
@@ -106,11 +171,11 @@ func (watcher FileWatcher) WatchDir(docParser parser.DocParser, dirName, docPath
 			select {
 			case event, ok := <-w.Events:
 				if !ok {
-					errChan <- fmt.Errorf("watcher event channel closed unexpectedly")
+					report(fmt.Errorf("watcher event channel closed unexpectedly"))
 					return
 				}
 
-				if shouldIgnore(event.Name, docPath) {
+				if watcher.shouldIgnore(event.Name, docPath) {
 					color.Magenta("Ignored event on: %s", event.Name)
 					continue
 				}
@@ -128,10 +193,10 @@ func (watcher FileWatcher) WatchDir(docParser parser.DocParser, dirName, docPath
 
 			case err, ok := <-w.Errors:
 				if !ok {
-					errChan <- fmt.Errorf("watcher error channel closed unexpectedly")
+					report(fmt.Errorf("watcher error channel closed unexpectedly"))
 					return
 				}
-				errChan <- fmt.Errorf("watcher error: %w", err)
+				report(fmt.Errorf("watcher error: %w", err))
 				return
 			}
 		}
@@ -139,8 +204,21 @@ func (watcher FileWatcher) WatchDir(docParser parser.DocParser, dirName, docPath
 
 	select {
 	case err := <-errChan:
+		if ctx.Err() != nil {
+			return nil
+		}
 		return err
-	case <-done:
+	case <-ctx.Done():
+		color.HiYellow("Watch mode stopped")
 		return nil
 	}
 }
+
+func isStopped(stop chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
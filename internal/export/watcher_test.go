@@ -0,0 +1,61 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingExporter counts the exports of the watcher
+type countingExporter struct {
+	exports *atomic.Int32
+}
+
+func (exporter countingExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	exporter.exports.Add(1)
+	return nil
+}
+
+func TestFileWatcher_WatchDir_Stop(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "add.go")
+	assert.NoError(t, os.WriteFile(source, []byte("package math\n"), 0644))
+
+	exports := &atomic.Int32{}
+	watcher := FileWatcher{Exporter: countingExporter{exports: exports}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- watcher.WatchDir(ctx, parser.DocParser{Quiet: true}, dir, filepath.Join(dir, "doc"))
+	}()
+
+	// the watcher is ready once the first change is exported
+	assert.Eventually(t, func() bool {
+		if exports.Load() > 0 {
+			return true
+		}
+		os.WriteFile(source, []byte("package math\n\n// @description Adds\nfunc Add() {}\n"), 0644)
+		return false
+	}, 10*time.Second, time.Second)
+
+	// a change right before the stop is never exported after WatchDir returns
+	assert.NoError(t, os.WriteFile(source, []byte("package math\n"), 0644))
+	cancel()
+	assert.NoError(t, <-done)
+
+	exported := exports.Load()
+	time.Sleep(time.Second)
+	assert.Equal(t, exported, exports.Load())
+	// no goroutine of the watch is left
+	stacks := make([]byte, 1<<20)
+	assert.NotContains(t, string(stacks[:runtime.Stack(stacks, true)]), "FileWatcher.WatchDir")
+}
@@ -1,14 +1,19 @@
 package export
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/dterbah/zendoc/internal/changelog"
 	"github.com/dterbah/zendoc/internal/doc"
 	"github.com/dterbah/zendoc/internal/export/app"
-	"github.com/dterbah/zendoc/internal/export/helper"
+	"github.com/dterbah/zendoc/internal/search"
 	"github.com/dterbah/zendoc/internal/system"
 	"github.com/fatih/color"
 )
@@ -19,6 +24,8 @@ const TEMPLATE_GIT_LINK = "https://github.com/ZenDocLabs/zendoc-ui-template"
 @description Struct that implements the DocExporter interface and exports the documentation in a web-friendly format.
 @author Dorian TERBAH
 @field DocExporter DocExporter - Embedded base exporter providing common exporting behavior.
+@field Changelog bool - Value used to write the changelog of the version, compared with the previous version recorded in app.json
+@field Revision string - The git revision the documentation is generated from, HEAD when empty
 */
 type WebExporter struct {
 	DocExporter
@@ -30,41 +37,74 @@ type WebExporter struct {
 	Description string
 	FileSystem  system.FileSystem
 	CmdRunner   system.CommandRunner
+	Changelog   bool
+	Revision    string
 }
 
 /*
 @description Export the project documentation as JSON to stdout
+@param ctx context.Context - The context of the export
 @param projectDoc doc.ProjectDoc - The documentation to export
 @return error - An error if the export fails
-@example WebExporter{}.Export(projectDoc)
+@example WebExporter{}.Export(ctx, projectDoc)
 */
-func (webExport WebExporter) Export(projectDoc doc.ProjectDoc) error {
+func (webExport WebExporter) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
 	b, err := json.Marshal(projectDoc)
 	if err != nil {
 		return fmt.Errorf("error when exporting the documentation in JSON: %w", err)
 	}
 
-	currentPath, _ := os.Getwd()
-	docPath := filepath.Join(currentPath, webExport.DocPath, webExport.AppName)
+	docPath := filepath.Join(webExport.DocPath, webExport.AppName)
 
-	if err := webExport.ensureTemplate(docPath); err != nil {
+	if err := webExport.ensureTemplate(ctx, docPath); err != nil {
 		return err
 	}
 
-	if err := webExport.updateAppConfig(docPath, webExport.Version, webExport.Description); err != nil {
+	entry := app.NewVersionEntry(ctx, webExport.Version, webExport.Revision, projectDoc, webExport.CmdRunner)
+
+	// nothing is written once the export is cancelled
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	color.Green("App config file updated !")
+	// the files are written together, app.json last, so that a failure never lists a version without its data
+	transaction := system.NewTransaction(webExport.FileSystem)
+	defer transaction.Rollback()
 
-	if err := webExport.writeDocumentationFile(docPath, b); err != nil {
+	changelogWritten := false
+	if webExport.Changelog {
+		written, err := webExport.writeChangelog(transaction, docPath, projectDoc)
+		if err != nil {
+			return err
+		}
+		changelogWritten = written
+	}
+
+	if err := webExport.writeDocumentationFile(transaction, docPath, b); err != nil {
 		return err
 	}
 
-	if err := webExport.writeEnvFile(docPath, webExport.GitLink, webExport.AppName, webExport.MainBranch); err != nil {
+	if err := webExport.writeSearchIndex(transaction, docPath, projectDoc); err != nil {
 		return err
 	}
 
+	if err := webExport.writeEnvFile(transaction, docPath, webExport.GitLink, webExport.AppName, webExport.MainBranch); err != nil {
+		return err
+	}
+
+	if err := webExport.updateAppConfig(transaction, docPath, entry, webExport.Description); err != nil {
+		return err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return fmt.Errorf("error when saving your project documentation: %w", err)
+	}
+
+	if changelogWritten {
+		color.Green("Changelog of v%s saved!", webExport.Version)
+	}
+	color.Green("Version file updated!")
+	color.Green("App config file updated !")
 	color.Green("Env file updated !")
 
 	color.Green("Documentation v%s saved!", webExport.Version)
@@ -72,64 +112,169 @@ func (webExport WebExporter) Export(projectDoc doc.ProjectDoc) error {
 }
 
 // ensureTemplate checks if the template exists, and installs it if not
-func (webExport WebExporter) ensureTemplate(docPath string) error {
-	if helper.IsFileExist(docPath) {
+func (webExport WebExporter) ensureTemplate(ctx context.Context, docPath string) error {
+	if webExport.FileSystem.FileExists(docPath) {
 		return nil
 	}
 
 	color.HiYellow("No documentation found, installing template ...")
-	return webExport.installWebTemplate(filepath.Dir(docPath), webExport.AppName)
+	return webExport.installWebTemplate(ctx, filepath.Dir(docPath), webExport.AppName)
 }
 
-func (webExport WebExporter) updateAppConfig(docPath, version, description string) error {
-	appPath := filepath.Join(docPath, "src", "assets", "app.json")
-	if err := app.UpdateAppConfig(appPath, version, description); err != nil {
+func (webExport WebExporter) updateAppConfig(transaction *system.Transaction, docPath string, entry app.VersionEntry, description string) error {
+	appPath := filepath.Join(WebAssetsDir(docPath), app.APP_CONFIG_FILE)
+	appConfig, err := app.AppConfigWithVersion(webExport.FileSystem, appPath, entry, description)
+	if err != nil {
+		return fmt.Errorf("error when updating the versions of your documentation: %w", err)
+	}
+
+	content, err := appConfig.Marshal()
+	if err != nil {
+		return fmt.Errorf("error when updating the versions of your documentation: %w", err)
+	}
+	if err := transaction.WriteFile(appPath, content, 0644); err != nil {
 		return fmt.Errorf("error when updating the versions of your documentation: %w", err)
 	}
-	color.Green("Version file updated!")
 	return nil
 }
 
+/*
+@description Compute the directory of the web application holding the app.json file and the files of the versions
+@param appDir string - The directory of the web application
+@return string - The assets directory
+@example WebAssetsDir("doc/zendoc") => "doc/zendoc/src/assets"
+@author Dorian TERBAH
+*/
+func WebAssetsDir(appDir string) string {
+	return filepath.Join(appDir, "src", "assets")
+}
+
+/*
+@description Compute the path of the documentation file of a version in the web application
+@param appDir string - The directory of the web application
+@param version string - The version of the documentation
+@return string - The path of the doc-<version>.json file
+@example WebDocumentationFile("doc/zendoc", "1.0.0") => "doc/zendoc/src/assets/doc-1.0.0.json"
+@author Dorian TERBAH
+*/
+func WebDocumentationFile(appDir, version string) string {
+	return filepath.Join(WebAssetsDir(appDir), fmt.Sprintf("doc-%s.json", version))
+}
+
 // writeDocumentationFile saves the doc content as a JSON file
-func (webExport WebExporter) writeDocumentationFile(docPath string, content []byte) error {
-	docFile := filepath.Join(docPath, "src", "assets", fmt.Sprintf("doc-%s.json", webExport.Version))
-	if err := webExport.FileSystem.WriteFile(docFile, content, 0644); err != nil {
+func (webExport WebExporter) writeDocumentationFile(transaction *system.Transaction, docPath string, content []byte) error {
+	docFile := WebDocumentationFile(docPath, webExport.Version)
+	if err := transaction.WriteFile(docFile, content, 0644); err != nil {
 		return fmt.Errorf("error when saving your project documentation: %w", err)
 	}
 	return nil
 }
 
-func (webExport WebExporter) writeEnvFile(docPath, gitLink, appName, mainBranch string) error {
+// writeSearchIndex saves the search index of the version next to its documentation file
+func (webExport WebExporter) writeSearchIndex(transaction *system.Transaction, docPath string, projectDoc doc.ProjectDoc) error {
+	content, err := search.Build(projectDoc).MarshalCompact()
+	if err != nil {
+		return err
+	}
+
+	indexFile := filepath.Join(WebAssetsDir(docPath), fmt.Sprintf("search-index-%s.json", webExport.Version))
+	if err := transaction.WriteFile(indexFile, content, 0644); err != nil {
+		return fmt.Errorf("error when saving the search index: %w", err)
+	}
+	return nil
+}
+
+// writeChangelog compares the documentation with the previous version, and stages the result in the CHANGELOG.md file of the doc path and as changelog-<version>.json for the UI. It returns false when there is no previous version.
+func (webExport WebExporter) writeChangelog(transaction *system.Transaction, docPath string, projectDoc doc.ProjectDoc) (bool, error) {
+	appPath := filepath.Join(WebAssetsDir(docPath), app.APP_CONFIG_FILE)
+	if !webExport.FileSystem.FileExists(appPath) {
+		color.HiYellow("No previous version, the changelog is skipped")
+		return false, nil
+	}
+
+	appConfig, err := app.LoadAppConfig(webExport.FileSystem, appPath)
+	if err != nil {
+		return false, fmt.Errorf("error when reading the versions of your documentation: %w", err)
+	}
+
+	previous := changelog.PreviousVersion(appConfig.VersionNames(), webExport.Version)
+	if previous == "" {
+		color.HiYellow("No version before v%s, the changelog is skipped", webExport.Version)
+		return false, nil
+	}
+
+	file, err := webExport.FileSystem.Open(WebDocumentationFile(docPath, previous))
+	if err != nil {
+		color.HiYellow("No documentation found for v%s, the changelog is skipped", previous)
+		return false, nil
+	}
+	defer file.Close()
+
+	previousDoc, err := doc.LoadProjectDoc(file)
+	if err != nil {
+		return false, fmt.Errorf("error when reading the documentation of v%s: %w", previous, err)
+	}
+
+	entry := changelog.Build(previous, *previousDoc, webExport.Version, projectDoc, time.Now().Format(time.DateOnly))
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return false, fmt.Errorf("error when exporting the changelog in JSON: %w", err)
+	}
+	changelogFile := filepath.Join(WebAssetsDir(docPath), fmt.Sprintf("changelog-%s.json", webExport.Version))
+	if err := transaction.WriteFile(changelogFile, content, 0644); err != nil {
+		return false, fmt.Errorf("error when saving the changelog: %w", err)
+	}
+
+	markdownFile := filepath.Join(filepath.Dir(docPath), changelog.CHANGELOG_FILE)
+	existing, err := webExport.FileSystem.ReadFile(markdownFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Errorf("error when reading %s: %w", markdownFile, err)
+	}
+	if err := transaction.WriteFile(markdownFile, []byte(entry.Insert(string(existing))), 0644); err != nil {
+		return false, fmt.Errorf("error when saving the changelog: %w", err)
+	}
+
+	return true, nil
+}
+
+func (webExport WebExporter) writeEnvFile(transaction *system.Transaction, docPath, gitLink, appName, mainBranch string) error {
 	envFile := filepath.Join(docPath, ".env")
 	fileContent := fmt.Sprintf("VITE_GIT_LINK=%s\nVITE_APP_NAME=%s\nVITE_MAIN_BRANCH=%s", gitLink, appName, mainBranch)
 
-	if err := webExport.FileSystem.WriteFile(envFile, []byte(fileContent), 0644); err != nil {
+	if err := transaction.WriteFile(envFile, []byte(fileContent), 0644); err != nil {
 		return fmt.Errorf("error when saving your project documentation: %w", err)
 	}
 	return nil
 }
 
-// installWebTemplate clones the template repo and installs its dependencies
-func (webExport WebExporter) installWebTemplate(docPath string, appName string) error {
+// installWebTemplate clones the template repo and installs its dependencies. A failed or cancelled installation is removed, so that the next export installs it again.
+func (webExport WebExporter) installWebTemplate(ctx context.Context, docPath string, appName string) error {
 	err := webExport.FileSystem.MkdirAll(docPath, os.ModePerm)
 	if err != nil {
 		return err
 	}
 
-	_, err = webExport.CmdRunner.Execute(docPath, "git", "clone", TEMPLATE_GIT_LINK)
+	clonePath := filepath.Join(docPath, "zendoc-ui-template")
+	appPath := filepath.Join(docPath, appName)
+
+	_, err = webExport.CmdRunner.Execute(ctx, docPath, "git", "clone", TEMPLATE_GIT_LINK)
 
 	if err != nil {
+		webExport.FileSystem.RemoveAll(clonePath)
 		return err
 	}
 
-	err = webExport.FileSystem.Rename(filepath.Join(docPath, "zendoc-ui-template"), filepath.Join(docPath, appName))
+	err = webExport.FileSystem.Rename(clonePath, appPath)
 	if err != nil {
+		webExport.FileSystem.RemoveAll(clonePath)
 		return err
 	}
 
-	_, err = webExport.CmdRunner.Execute(filepath.Join(docPath, appName), "npm", "i")
+	_, err = webExport.CmdRunner.Execute(ctx, appPath, "npm", "i")
 
 	if err != nil {
+		webExport.FileSystem.RemoveAll(appPath)
 		return err
 	}
 
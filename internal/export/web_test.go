@@ -0,0 +1,97 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/changelog"
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/export/app"
+	"github.com/dterbah/zendoc/internal/system"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTemplateRunner clones an empty template into the in-memory file system, and fails the installation of its dependencies
+type fakeTemplateRunner struct {
+	fileSystem *system.MemoryFileSystem
+	npmErr     error
+}
+
+func (f *fakeTemplateRunner) Execute(ctx context.Context, dir string, name string, arg ...string) ([]byte, error) {
+	if name == "git" {
+		return nil, f.fileSystem.WriteFile(filepath.Join(dir, "zendoc-ui-template", "package.json"), []byte("{}"), 0644)
+	}
+	return nil, f.npmErr
+}
+
+func (f *fakeTemplateRunner) ExecuteWithInput(ctx context.Context, dir string, input []byte, name string, arg ...string) ([]byte, error) {
+	return nil, errors.New("unexpected call")
+}
+
+func TestWebExporter_InstallWebTemplate(t *testing.T) {
+	fileSystem := system.NewMemoryFileSystem()
+	exporter := WebExporter{FileSystem: fileSystem, CmdRunner: &fakeTemplateRunner{fileSystem: fileSystem}}
+
+	assert.NoError(t, exporter.installWebTemplate(context.Background(), "doc", "zendoc"))
+	assert.True(t, fileSystem.FileExists(filepath.Join("doc", "zendoc", "package.json")))
+	assert.False(t, fileSystem.FileExists(filepath.Join("doc", "zendoc-ui-template")))
+}
+
+func TestWebExporter_InstallWebTemplate_Cancelled(t *testing.T) {
+	fileSystem := system.NewMemoryFileSystem()
+	runner := &fakeTemplateRunner{fileSystem: fileSystem, npmErr: context.Canceled}
+	exporter := WebExporter{FileSystem: fileSystem, CmdRunner: runner}
+
+	// a template without its dependencies would be kept by the next export, so it is removed
+	assert.ErrorIs(t, exporter.installWebTemplate(context.Background(), "doc", "zendoc"), context.Canceled)
+	assert.False(t, fileSystem.FileExists(filepath.Join("doc", "zendoc")))
+	assert.True(t, fileSystem.FileExists("doc"))
+}
+
+func TestWebExporter_Export(t *testing.T) {
+	fileSystem := system.NewMemoryFileSystem()
+	// the template is already installed, so that the export only writes the files of the versions
+	assert.NoError(t, fileSystem.MkdirAll(WebAssetsDir(filepath.Join("doc", "zendoc")), 0755))
+	exporter := WebExporter{
+		GitLink:     "https://github.com/dterbah/zendoc",
+		AppName:     "zendoc",
+		MainBranch:  "main",
+		DocPath:     "doc",
+		Version:     "1.0.0",
+		Description: "Doc generator",
+		FileSystem:  fileSystem,
+		Changelog:   true,
+	}
+	assert.NoError(t, exporter.Export(context.Background(), doctest.Sample()))
+
+	nextDoc := doctest.Sample()
+	parserFile := &nextDoc.PackageDocs["parser"][0]
+	parserFile.Docs = append(parserFile.Docs, &doc.FuncDoc{BaseDoc: doc.BaseDoc{Name: "ParseDocForFile", Type: doc.FUNCTION_TYPE}, Params: []doc.Param{}})
+	exporter.Version = "1.1.0"
+	assert.NoError(t, exporter.Export(context.Background(), nextDoc))
+
+	assetsDir := WebAssetsDir(filepath.Join("doc", "zendoc"))
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		assert.True(t, fileSystem.FileExists(WebDocumentationFile(filepath.Join("doc", "zendoc"), version)))
+		assert.True(t, fileSystem.FileExists(filepath.Join(assetsDir, "search-index-"+version+".json")))
+	}
+	assert.False(t, fileSystem.FileExists(filepath.Join(assetsDir, "changelog-1.0.0.json")))
+	assert.True(t, fileSystem.FileExists(filepath.Join(assetsDir, "changelog-1.1.0.json")))
+
+	appConfig, err := app.LoadAppConfig(fileSystem, filepath.Join(assetsDir, app.APP_CONFIG_FILE))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.0.0", "1.1.0"}, appConfig.VersionNames())
+	assert.Equal(t, "1.1.0", appConfig.Latest)
+
+	markdown, err := fileSystem.ReadFile(filepath.Join("doc", changelog.CHANGELOG_FILE))
+	assert.NoError(t, err)
+	assert.Contains(t, string(markdown), "## [1.1.0]")
+	assert.Contains(t, string(markdown), "ParseDocForFile")
+
+	env, err := fileSystem.ReadFile(filepath.Join("doc", "zendoc", ".env"))
+	assert.NoError(t, err)
+	assert.Equal(t, "VITE_GIT_LINK=https://github.com/dterbah/zendoc\nVITE_APP_NAME=zendoc\nVITE_MAIN_BRANCH=main", string(env))
+}
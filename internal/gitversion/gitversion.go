@@ -0,0 +1,165 @@
+package gitversion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/semver"
+	"github.com/dterbah/zendoc/internal/system"
+)
+
+// AUTO_VERSION is the value of the version resolved from the git tags
+const AUTO_VERSION = "auto"
+
+// DEV_SUFFIX is the pre-release of the versions resolved from an untagged commit or a dirty tree
+const DEV_SUFFIX = "dev"
+
+// NO_TAG_VERSION is the version resolved when no tag matches
+const NO_TAG_VERSION = "0.0.0-" + DEV_SUFFIX
+
+/*
+@description Struct to represent the output of git describe
+@author Dorian TERBAH
+@field Tag string - The nearest tag matching the prefix
+@field Distance int - The number of commits since the tag
+@field Dirty bool - true if the working tree has uncommitted changes
+*/
+type Description struct {
+	Tag      string
+	Distance int
+	Dirty    bool
+}
+
+/*
+@description Resolve the documentation version from the nearest git tag. A tagged and clean tree gives the version of its tag, otherwise the next patch version with the "dev" pre-release is used, so the documentation of a release is never overwritten.
+@param ctx context.Context - The context of the git commands
+@param cmdRunner system.CommandRunner - The runner of the git commands
+@param dir string - The directory of the git repository
+@param tagPrefix string - The prefix of the tags to consider, such as "module/" for "module/v1.2.0" tags in a monorepo
+@param ref string - The git ref to describe, or an empty string for the working tree
+@return (string, error) - The version without its prefix and "v" (e.g. "1.2.0" or "1.2.1-dev") and an error if git cannot describe the tree
+@example Resolve(ctx, runner, "", "module/", "") => "1.2.0", nil
+@author Dorian TERBAH
+*/
+func Resolve(ctx context.Context, cmdRunner system.CommandRunner, dir, tagPrefix, ref string) (string, error) {
+	args := []string{"describe", "--tags", "--long", "--match", tagPrefix + "*"}
+	if ref == "" {
+		args = append(args, "--dirty")
+	} else {
+		args = append(args, ref)
+	}
+
+	output, err := cmdRunner.Execute(ctx, dir, "git", args...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", err
+		}
+		if strings.Contains(string(output), "No names found") || strings.Contains(string(output), "No tags can describe") {
+			return NO_TAG_VERSION, nil
+		}
+		return "", fmt.Errorf("error when describing the git tree: %s", strings.TrimSpace(string(output)))
+	}
+
+	description, err := Parse(strings.TrimSpace(string(output)))
+	if err != nil {
+		return "", err
+	}
+
+	return description.Version(tagPrefix), nil
+}
+
+/*
+@description Parse the output of "git describe --tags --long --dirty"
+@param output string - The description, such as "module/v1.2.0-3-g3ee42db-dirty"
+@return (Description, error) - The parsed description and an error if the output is not a long description
+@author Dorian TERBAH
+*/
+func Parse(output string) (Description, error) {
+	description := Description{}
+
+	text, dirty := strings.CutSuffix(output, "-dirty")
+	description.Dirty = dirty
+
+	// the tag may contain dashes, the distance and the hash are the last parts
+	parts := strings.Split(text, "-")
+	if len(parts) < 3 || !strings.HasPrefix(parts[len(parts)-1], "g") {
+		return Description{}, fmt.Errorf("invalid git description \"%s\"", output)
+	}
+
+	distance, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil {
+		return Description{}, fmt.Errorf("invalid git description \"%s\"", output)
+	}
+
+	description.Distance = distance
+	description.Tag = strings.Join(parts[:len(parts)-2], "-")
+	return description, nil
+}
+
+/*
+@description Compute the documentation version of a description
+@param tagPrefix string - The prefix removed from the tag
+@return string - The version of the tag for a clean tagged tree, the next patch version with the "dev" pre-release otherwise
+@example Description{Tag: "v1.2.0", Distance: 3}.Version("") => "1.2.1-dev"
+@author Dorian TERBAH
+*/
+func (description Description) Version(tagPrefix string) string {
+	version := strings.TrimPrefix(strings.TrimPrefix(description.Tag, tagPrefix), "v")
+	if description.Distance == 0 && !description.Dirty {
+		return version
+	}
+
+	parsed, err := semver.Parse(version)
+	if err != nil {
+		return version + "-" + DEV_SUFFIX
+	}
+
+	// the dev version of a pre-release tag stays before its release, e.g. 1.2.0-rc.1.dev
+	if parsed.Prerelease != "" {
+		parsed.Prerelease += "." + DEV_SUFFIX
+		return parsed.String()
+	}
+
+	parsed.Patch++
+	parsed.Prerelease = DEV_SUFFIX
+	return parsed.String()
+}
+
+/*
+@description List the release tags of the repository, the oldest version first. Only the tags starting with the prefix and followed by a semantic version are kept.
+@param ctx context.Context - The context of the git command
+@param cmdRunner system.CommandRunner - The runner of the git commands
+@param dir string - The directory of the git repository
+@param tagPrefix string - The prefix of the tags to consider
+@return ([]string, error) - The tags, sorted by semantic version, and an error if git cannot list them
+@example Tags(ctx, runner, "", "module/") => []string{"module/v1.0.0", "module/v1.1.0"}, nil
+@author Dorian TERBAH
+*/
+func Tags(ctx context.Context, cmdRunner system.CommandRunner, dir, tagPrefix string) ([]string, error) {
+	output, err := cmdRunner.Execute(ctx, dir, "git", "tag", "--list", tagPrefix+"*")
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error when listing the git tags: %s", strings.TrimSpace(string(output)))
+	}
+
+	tags := []string{}
+	versions := map[string]semver.Version{}
+	for _, tag := range strings.Fields(string(output)) {
+		version, err := semver.Parse(strings.TrimPrefix(tag, tagPrefix))
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+		versions[tag] = version
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return versions[tags[i]].Compare(versions[tags[j]]) < 0
+	})
+	return tags, nil
+}
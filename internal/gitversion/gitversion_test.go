@@ -0,0 +1,85 @@
+package gitversion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeGitRunner struct {
+	output string
+	err    error
+	args   []string
+}
+
+func (r *fakeGitRunner) Execute(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	r.args = args
+	return []byte(r.output), r.err
+}
+
+func (r *fakeGitRunner) ExecuteWithInput(ctx context.Context, dir string, input []byte, name string, args ...string) ([]byte, error) {
+	return nil, nil
+}
+
+func TestParse(t *testing.T) {
+	description, err := Parse("module/v1.2.0-rc-1-3-g3ee42db-dirty")
+	assert.NoError(t, err)
+	assert.Equal(t, Description{Tag: "module/v1.2.0-rc-1", Distance: 3, Dirty: true}, description)
+
+	for _, output := range []string{"3ee42db", "v1.2.0", "v1.2.0-x-g3ee42db"} {
+		_, err := Parse(output)
+		assert.Error(t, err, output)
+	}
+}
+
+func TestDescription_Version(t *testing.T) {
+	tests := map[string]struct {
+		description Description
+		prefix      string
+		expected    string
+	}{
+		"tagged":             {description: Description{Tag: "v1.2.0"}, expected: "1.2.0"},
+		"prefix":             {description: Description{Tag: "module/v1.2.0"}, prefix: "module/", expected: "1.2.0"},
+		"untagged commit":    {description: Description{Tag: "v1.2.0", Distance: 3}, expected: "1.2.1-dev"},
+		"dirty":              {description: Description{Tag: "v1.2.0", Dirty: true}, expected: "1.2.1-dev"},
+		"pre-release":        {description: Description{Tag: "v2.0.0-rc.1", Distance: 1}, expected: "2.0.0-rc.1.dev"},
+		"not semantic":       {description: Description{Tag: "release-5", Dirty: true}, expected: "release-5-dev"},
+		"not semantic clean": {description: Description{Tag: "release-5"}, expected: "release-5"},
+	}
+
+	for name, test := range tests {
+		assert.Equal(t, test.expected, test.description.Version(test.prefix), name)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	runner := &fakeGitRunner{output: "module/v1.2.0-0-g3ee42db\n"}
+	version, err := Resolve(context.Background(), runner, "", "module/", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.0", version)
+	assert.Equal(t, []string{"describe", "--tags", "--long", "--match", "module/*", "--dirty"}, runner.args)
+
+	_, err = Resolve(context.Background(), runner, "", "module/", "module/v1.2.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"describe", "--tags", "--long", "--match", "module/*", "module/v1.2.0"}, runner.args)
+
+	runner = &fakeGitRunner{output: "fatal: No names found, cannot describe anything.\n", err: fmt.Errorf("exit status 128")}
+	version, err = Resolve(context.Background(), runner, "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, NO_TAG_VERSION, version)
+
+	runner = &fakeGitRunner{output: "fatal: not a git repository\n", err: fmt.Errorf("exit status 128")}
+	_, err = Resolve(context.Background(), runner, "", "", "")
+	assert.ErrorContains(t, err, "not a git repository")
+}
+
+func TestTags(t *testing.T) {
+	runner := &fakeGitRunner{output: "module/v1.10.0\nmodule/v1.2.0\nmodule/latest\nmodule/v1.2.0-rc.1\n"}
+	tags, err := Tags(context.Background(), runner, "", "module/")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"module/v1.2.0-rc.1", "module/v1.2.0", "module/v1.10.0"}, tags)
+	assert.Equal(t, []string{"tag", "--list", "module/*"}, runner.args)
+}
@@ -1,10 +1,12 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
@@ -20,15 +22,20 @@ const GO_EXTENSION = ".go"
 type DocParserFileValidator = func(string) bool
 type DocParserFunctionValidator = func(string) bool
 
+// FileDocVisitor receives the package name and the documentation of a parsed file
+type FileDocVisitor = func(pckName string, fileDoc doc.FileDoc) error
+
 /*
 @description Struct responsible for orchestrating validation logic when parsing documentation from Go source files. It holds a list of validators for files and functions to modularize and organize parsing rules and behaviors.
 @field FileValidators []DocParserFileValidator - A list of validators applied at the file level (e.g. checking file-level tags, imports, etc.)
 @field FunctionValidators []DocParserFunctionValidator - A list of validators specifically designed to validate function-level documentation (e.g. param/return tag parsing, required fields, etc.)
+@field Quiet bool - Disable the progress messages, for commands printing their own output
 @author Dorian TERBAH
 */
 type DocParser struct {
 	FileValidators     []DocParserFileValidator
 	FunctionValidators []DocParserFunctionValidator
+	Quiet              bool
 }
 
 /*
@@ -63,73 +70,102 @@ func (docParser DocParser) isValidateFunction(name string) bool {
 	return true
 }
 
+// log prints a progress message, unless the parser is quiet
+func (docParser DocParser) log(print func(format string, a ...interface{}), format string, a ...interface{}) {
+	if !docParser.Quiet {
+		print(format, a...)
+	}
+}
+
 /*
 @description Recursively parse documentation in a directory and its subdirectories
+@param ctx context.Context - The context of the parsing, which stops when the context is done
 @param dirPath string - The root path to scan
 @param currentPath string - The relative path used for output (maintains relative structure)
 @return *doc.ProjectDoc, error - The parsed project documentation and an error if something went wrong
-@example ParseDocForDir("./myproject", "")
+@example ParseDocForDir(ctx, "./myproject", "")
+@author Dorian TERBAH
+*/
+func (docParser DocParser) ParseDocForDir(ctx context.Context, dirPath string, currentPath string) (*doc.ProjectDoc, error) {
+	return docParser.ParseDocForFS(ctx, os.DirFS(dirPath), ".", currentPath)
+}
+
+/*
+@description Recursively parse documentation in a directory of a file system, such as an archive, an embedded tree or an in-memory file system
+@param ctx context.Context - The context of the parsing, checked before each file
+@param fsys fs.FS - The file system holding the sources
+@param dir string - The slash-separated directory to scan, "." for the root of the file system
+@param currentPath string - The relative path used for output (maintains relative structure)
+@return *doc.ProjectDoc, error - The parsed project documentation and an error if something went wrong or if the context is done
+@example ParseDocForFS(ctx, os.DirFS("./myproject"), ".", "")
 @author Dorian TERBAH
 */
-func (docParser DocParser) ParseDocForDir(dirPath string, currentPath string) (*doc.ProjectDoc, error) {
-	entries, err := os.ReadDir(dirPath)
-	projectDoc := &doc.ProjectDoc{
-		PackageDocs: make(map[string][]doc.FileDoc),
+func (docParser DocParser) ParseDocForFS(ctx context.Context, fsys fs.FS, dir string, currentPath string) (*doc.ProjectDoc, error) {
+	projectDoc := doc.NewProjectDoc()
+
+	err := docParser.WalkDocForFS(ctx, fsys, dir, currentPath, func(pckName string, fileDoc doc.FileDoc) error {
+		projectDoc.PackageDocs[pckName] = append(projectDoc.PackageDocs[pckName], fileDoc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return projectDoc, nil
+}
+
+/*
+@description Recursively parse documentation in a directory of a file system, giving the documentation of each file to the visitor as soon as it is parsed, instead of keeping the whole project in memory. The files without documented items are not visited.
+@param ctx context.Context - The context of the parsing, checked before each file
+@param fsys fs.FS - The file system holding the sources
+@param dir string - The slash-separated directory to scan, "." for the root of the file system
+@param currentPath string - The relative path used for output (maintains relative structure)
+@param visit FileDocVisitor - The function receiving the package name and the documentation of each file
+@return error - An error if a file cannot be read, if the visitor fails or if the context is done
+@example WalkDocForFS(ctx, os.DirFS("./myproject"), ".", "", func(pckName string, fileDoc doc.FileDoc) error { ... })
+@author Dorian TERBAH
+*/
+func (docParser DocParser) WalkDocForFS(ctx context.Context, fsys fs.FS, dir string, currentPath string, visit FileDocVisitor) error {
+	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil {
-		return nil, fmt.Errorf("error when listing the files of the dir %s", dirPath)
+		return fmt.Errorf("error when listing the files of the dir %s", dir)
 	}
 
 	for _, entry := range entries {
-		fullPath := filepath.Join(dirPath, entry.Name())
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		fullPath := path.Join(dir, entry.Name())
 		if entry.Type().IsRegular() {
 			fileName := entry.Name()
-			if filepath.Ext(fullPath) == GO_EXTENSION {
+			if path.Ext(fullPath) == GO_EXTENSION {
 				if !docParser.isValidateFileForDoc(fileName) {
-					color.HiYellow("File \"%s\" skipped", fileName)
+					docParser.log(color.HiYellow, "File \"%s\" skipped", fileName)
 					continue
 				}
 
-				color.Green("File \"%s\" being processed...", path.Base(fileName))
+				docParser.log(color.Green, "File \"%s\" being processed...", path.Base(fileName))
 
-				pckName, fileDoc := docParser.ParseDocForFile(fullPath)
-				_, ok := projectDoc.PackageDocs[pckName]
-				if !ok {
-					projectDoc.PackageDocs[pckName] = []doc.FileDoc{}
+				pckName, fileDoc, err := docParser.ParseDocForFSFile(fsys, fullPath)
+				if err != nil {
+					return err
 				}
 				fileDoc.Path = filepath.Join(currentPath, entry.Name())
 				if len(fileDoc.Docs) > 0 {
-					projectDoc.PackageDocs[pckName] = append(projectDoc.PackageDocs[pckName], *fileDoc)
+					if err := visit(pckName, *fileDoc); err != nil {
+						return err
+					}
 				}
 			}
 		} else if entry.Type().IsDir() {
-			dirDoc, err := docParser.ParseDocForDir(fullPath, filepath.Join(currentPath, entry.Name()))
-			if err != nil {
-				return nil, fmt.Errorf("error when retrieving doc of the directory %s", fullPath)
-			}
-			// iterate through the doc
-			for pckName, docs := range dirDoc.PackageDocs {
-				_, ok := projectDoc.PackageDocs[pckName]
-				if !ok {
-					projectDoc.PackageDocs[pckName] = []doc.FileDoc{}
-				}
-
-				projectDoc.PackageDocs[pckName] = append(projectDoc.PackageDocs[pckName], docs...)
+			if err := docParser.WalkDocForFS(ctx, fsys, fullPath, filepath.Join(currentPath, entry.Name()), visit); err != nil {
+				return err
 			}
 		}
 	}
 
-	removeEmptyPackages(projectDoc)
-
-	return projectDoc, nil
-}
-
-func removeEmptyPackages(projectDoc *doc.ProjectDoc) {
-	for pckName, doc := range projectDoc.PackageDocs {
-		if len(doc) == 0 {
-			delete(projectDoc.PackageDocs, pckName)
-		}
-	}
+	return nil
 }
 
 // @description Parse the documentation for a single file
@@ -140,32 +176,69 @@ func removeEmptyPackages(projectDoc *doc.ProjectDoc) {
 // @return (string, []doc.FuncDoc) - The associated doc for the file. If no package is mentioned, it return an empty string and nil
 // @example ParseDocForFile("myfile.go")
 func (docParser DocParser) ParseDocForFile(filePath string) (string, *doc.FileDoc) {
-	// retrieve package name
-	packageName, err := getPackageName(filePath)
+	packageName, fileDoc, err := docParser.parseDocForSource(filePath, nil)
 	if err != nil {
+		docParser.log(color.Red, "%s", err)
 		return "", nil
 	}
+	return packageName, fileDoc
+}
 
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+/*
+@description Parse the documentation for a single file of a file system
+@param fsys fs.FS - The file system holding the file
+@param name string - The slash-separated path of the file in the file system
+@return (string, *doc.FileDoc, error) - The package name, the documentation of the file and an error if the file cannot be read or is not valid Go
+@example ParseDocForFSFile(os.DirFS("."), "internal/parser/parser.go")
+@author Dorian TERBAH
+*/
+func (docParser DocParser) ParseDocForFSFile(fsys fs.FS, name string) (string, *doc.FileDoc, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return "", nil, fmt.Errorf("error when reading the file %s: %w", name, err)
+	}
+	if content == nil {
+		content = []byte{}
+	}
 
-	docs := []any{}
+	return docParser.parseDocForSource(name, content)
+}
 
+// parseDocForSource parses a file, read from the disk when its content is nil
+func (docParser DocParser) parseDocForSource(filePath string, content []byte) (string, *doc.FileDoc, error) {
+	// a nil []byte would be parsed as an empty source
+	var src any
+	if content != nil {
+		src = content
+	}
+
+	// retrieve package name
+	packageName, err := getSourcePackageName(filePath, src)
 	if err != nil {
-		panic(err)
+		return "", nil, err
 	}
 
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return "", nil, fmt.Errorf("error when parsing the file %s: %w", filePath, err)
+	}
+
+	docs := []doc.DocItem{}
+
 	for _, decl := range node.Decls {
 		funcDecl, isFunction := decl.(*ast.FuncDecl)
 		if isFunction {
 			if !docParser.isValidateFunction(funcDecl.Name.Name) {
-				color.HiYellow("	Skip function \"%s\"", funcDecl.Name.Name)
+				docParser.log(color.HiYellow, "	Skip function \"%s\"", funcDecl.Name.Name)
 				continue
 			}
 
 			fd := docParser.ParseDocForFunction(funcDecl)
 			if fd != nil {
-				docs = append(docs, *fd)
+				fd.Position = newPosition(fset, funcDecl)
+				fd.Signature = FuncSignature(fset, funcDecl)
+				docs = append(docs, fd)
 			}
 
 			continue
@@ -184,7 +257,9 @@ func (docParser DocParser) ParseDocForFile(filePath string) (string, *doc.FileDo
 					if genDecl.Doc != nil {
 						sd := docParser.ParseDocForStruct(genDecl.Doc, typeSpec.Name.Name)
 						if sd != nil {
-							docs = append(docs, *sd)
+							sd.Position = newPosition(fset, typeDeclNode(genDecl, typeSpec))
+							sd.Signature = TypeSignature(fset, typeSpec)
+							docs = append(docs, sd)
 						}
 					}
 				}
@@ -193,7 +268,10 @@ func (docParser DocParser) ParseDocForFile(filePath string) (string, *doc.FileDo
 					if genDecl.Doc != nil {
 						id := docParser.ParseDocForInterface(genDecl.Doc, typeSpec.Name.Name, iface)
 						if id != nil {
-							docs = append(docs, *id)
+							id.Position = newPosition(fset, typeDeclNode(genDecl, typeSpec))
+							id.Signature = TypeSignature(fset, typeSpec)
+							describeMethods(fset, iface, id)
+							docs = append(docs, id)
 						}
 					}
 					continue
@@ -206,7 +284,7 @@ func (docParser DocParser) ParseDocForFile(filePath string) (string, *doc.FileDo
 	return packageName, &doc.FileDoc{
 		Docs:     docs,
 		FileName: filepath.Base(filePath),
-	}
+	}, nil
 }
 
 func (docParser DocParser) ParseDocForInterface(comments *ast.CommentGroup, name string, iface *ast.InterfaceType) *doc.InterfaceDoc {
@@ -222,7 +300,7 @@ func (docParser DocParser) ParseDocForInterface(comments *ast.CommentGroup, name
 	id := &doc.InterfaceDoc{
 		BaseDoc: doc.BaseDoc{
 			Name: name,
-			Type: "interface",
+			Type: doc.INTERFACE_TYPE,
 		},
 		Methods: []doc.FuncDoc{},
 	}
@@ -271,7 +349,7 @@ func (docParser DocParser) ParseDocForInterfaceMethod(docGroup *ast.CommentGroup
 		Doc:  docGroup,
 	})
 	if fd != nil {
-		fd.Type = "interface-method"
+		fd.Type = doc.INTERFACE_METHOD_TYPE
 	}
 	return fd
 }
@@ -299,7 +377,7 @@ func (docParser DocParser) ParseDocForStruct(structComments *ast.CommentGroup, n
 	}
 
 	sd.Name = name
-	sd.Type = "struct"
+	sd.Type = doc.STRUCT_TYPE
 
 	for _, line := range lines {
 		switch {
@@ -356,7 +434,7 @@ func (docParser DocParser) ParseDocForFunction(function *ast.FuncDecl) *doc.Func
 	}
 
 	fd.Name = function.Name.Name
-	fd.Type = "function"
+	fd.Type = doc.FUNCTION_TYPE
 
 	// Check if it's a method associated with a struct
 	if function.Recv != nil && len(function.Recv.List) > 0 {
@@ -415,6 +493,52 @@ func (docParser DocParser) ParseDocForFunction(function *ast.FuncDecl) *doc.Func
 	return fd
 }
 
+/*
+@description Compute the source position of a node
+@param fset *token.FileSet - The file set used to parse the file containing the node
+@param node ast.Node - The node to locate
+@return doc.Position - The start and end line and column of the node
+@author Dorian TERBAH
+*/
+func newPosition(fset *token.FileSet, node ast.Node) doc.Position {
+	start := fset.Position(node.Pos())
+	end := fset.Position(node.End())
+
+	return doc.Position{
+		StartLine:   start.Line,
+		StartColumn: start.Column,
+		EndLine:     end.Line,
+		EndColumn:   end.Column,
+	}
+}
+
+// typeDeclNode returns the whole declaration for a single type declaration, or only the spec inside a grouped one
+func typeDeclNode(genDecl *ast.GenDecl, typeSpec *ast.TypeSpec) ast.Node {
+	if genDecl.Lparen.IsValid() {
+		return typeSpec
+	}
+	return genDecl
+}
+
+// describeMethods locates the documented methods of an interface and renders their signature
+func describeMethods(fset *token.FileSet, iface *ast.InterfaceType, id *doc.InterfaceDoc) {
+	methods := map[string]*ast.Field{}
+	for _, method := range iface.Methods.List {
+		for _, methodName := range method.Names {
+			methods[methodName.Name] = method
+		}
+	}
+
+	for i := range id.Methods {
+		if method, ok := methods[id.Methods[i].Name]; ok {
+			id.Methods[i].Position = newPosition(fset, method)
+			if funcType, ok := method.Type.(*ast.FuncType); ok {
+				id.Methods[i].Signature = interfaceMethodSignature(fset, id.Methods[i].Name, funcType)
+			}
+		}
+	}
+}
+
 /*
 @description Sanitize and flatten comment lines (block or single-line) to a slice of clean strings
 @param doc *ast.CommentGroup - The group of AST comments to sanitize
@@ -460,12 +584,16 @@ func sanitizeLines(doc *ast.CommentGroup) []string {
 @example getPackageName("./parser.go") => parser
 */
 func getPackageName(filePath string) (string, error) {
+	return getSourcePackageName(filePath, nil)
+}
+
+func getSourcePackageName(filePath string, src any) (string, error) {
 	fset := token.NewFileSet()
 
-	node, err := parser.ParseFile(fset, filePath, nil, parser.PackageClauseOnly)
+	node, err := parser.ParseFile(fset, filePath, src, parser.PackageClauseOnly)
 
 	if err != nil {
-		return "", nil
+		return "", fmt.Errorf("error when reading the package of the file %s: %w", filePath, err)
 	}
 
 	return node.Name.Name, nil
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -8,6 +10,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/system"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -236,3 +240,148 @@ func TestParseDocForStruct_PartialTags(t *testing.T) {
 	assert.Equal(t, "string", structDoc.Fields[0].Type)
 	assert.Equal(t, "identifier", structDoc.Fields[0].Description)
 }
+
+func TestParseDocForFile_Positions(t *testing.T) {
+	docParser := DocParser{}
+	content := `package dummy
+
+// @description Adds two numbers
+// @param a int - first number
+// @return int - the result
+func Add(a int) int {
+	return a
+}
+
+/*
+@description A runner
+*/
+type Runner interface {
+	// @description Run the runner
+	Run() error
+}
+`
+	tmpFile := writeTempFile(t, "dummy.go", content)
+
+	_, fileDoc := docParser.ParseDocForFile(tmpFile)
+	assert.Len(t, fileDoc.Docs, 2)
+
+	fd := fileDoc.Docs[0].(*doc.FuncDoc)
+	assert.Equal(t, doc.Position{StartLine: 6, StartColumn: 1, EndLine: 8, EndColumn: 2}, fd.Position)
+
+	id := fileDoc.Docs[1].(*doc.InterfaceDoc)
+	assert.Equal(t, 13, id.Position.StartLine)
+	assert.Equal(t, 16, id.Position.EndLine)
+	assert.Len(t, id.Methods, 1)
+	assert.Equal(t, 15, id.Methods[0].Position.StartLine)
+	assert.Equal(t, 2, id.Methods[0].Position.StartColumn)
+}
+
+func TestParseDocForFile_Signatures(t *testing.T) {
+	docParser := DocParser{}
+	content := `package dummy
+
+// @description Runs the command
+func (r *Runner) Run(dir string, args ...string) (output []byte, err error) {
+	return nil, nil
+}
+
+/*
+@description A runner
+@field Dir string - The directory
+*/
+type Runner struct {
+	// the directory
+	Dir string ` + "`json:\"dir\"`" + `
+	env []string
+}
+
+/*
+@description An executor
+*/
+type Executor interface {
+	// @description Execute a command
+	Execute(name string) error
+}
+`
+	tmpFile := writeTempFile(t, "dummy.go", content)
+
+	_, fileDoc := docParser.ParseDocForFile(tmpFile)
+	assert.Len(t, fileDoc.Docs, 3)
+
+	fd := fileDoc.Docs[0].(*doc.FuncDoc)
+	assert.Equal(t, "func (*Runner) Run(dir string, args ...string) (output []byte, err error)", fd.Signature)
+
+	sd := fileDoc.Docs[1].(*doc.StructDoc)
+	assert.Equal(t, "type Runner struct {\n\tDir\tstring\t`json:\"dir\"`\n\tenv\t[]string\n}", sd.Signature)
+
+	id := fileDoc.Docs[2].(*doc.InterfaceDoc)
+	assert.Equal(t, "type Executor interface {\n\tExecute(name string) error\n}", id.Signature)
+	assert.Equal(t, "Execute(name string) error", id.Methods[0].Signature)
+}
+
+func TestParseDocForFS(t *testing.T) {
+	docParser := DocParser{Quiet: true}
+	fsys := system.NewMemoryFileSystem()
+	assert.NoError(t, fsys.WriteFile("math/add.go", []byte(`package math
+
+// @description Adds two numbers
+func Add(a int, b int) int {
+	return a + b
+}
+`), 0644))
+	assert.NoError(t, fsys.WriteFile("math/sub/sub.go", []byte(`package sub
+
+// @description Subtracts two numbers
+func Sub(a int, b int) int {
+	return a - b
+}
+`), 0644))
+	assert.NoError(t, fsys.WriteFile("README.md", []byte("# math"), 0644))
+
+	projectDoc, err := docParser.ParseDocForFS(context.Background(), fsys, ".", "")
+	assert.NoError(t, err)
+	assert.Len(t, projectDoc.PackageDocs, 2)
+	assert.Equal(t, filepath.Join("math", "add.go"), projectDoc.PackageDocs["math"][0].Path)
+	assert.Equal(t, filepath.Join("math", "sub", "sub.go"), projectDoc.PackageDocs["sub"][0].Path)
+	assert.Equal(t, "Subtracts two numbers", projectDoc.PackageDocs["sub"][0].Docs[0].GetBaseDoc().Description)
+
+	_, _, err = docParser.ParseDocForFSFile(fsys, "math/missing.go")
+	assert.Error(t, err)
+
+	assert.NoError(t, fsys.WriteFile("math/testdata/broken.go", []byte("package math\n\nfunc Broken( {\n"), 0644))
+	assert.NoError(t, fsys.WriteFile("math/testdata/nopackage.go", []byte("func Add() {}\n"), 0644))
+	_, _, err = docParser.ParseDocForFSFile(fsys, "math/testdata/broken.go")
+	assert.ErrorContains(t, err, "error when parsing the file math/testdata/broken.go")
+	_, _, err = docParser.ParseDocForFSFile(fsys, "math/testdata/nopackage.go")
+	assert.ErrorContains(t, err, "error when reading the package of the file math/testdata/nopackage.go")
+	_, err = docParser.ParseDocForFS(context.Background(), fsys, ".", "")
+	assert.Error(t, err)
+	assert.NoError(t, fsys.RemoveAll("math/testdata"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = docParser.ParseDocForFS(ctx, fsys, ".", "")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWalkDocForFS(t *testing.T) {
+	docParser := DocParser{Quiet: true}
+	fsys := system.NewMemoryFileSystem()
+	assert.NoError(t, fsys.WriteFile("math/add.go", []byte("package math\n\n// @description Adds two numbers\nfunc Add(a int, b int) int {\n\treturn a + b\n}\n"), 0644))
+	assert.NoError(t, fsys.WriteFile("math/empty.go", []byte("package math\n"), 0644))
+	assert.NoError(t, fsys.WriteFile("math/sub/sub.go", []byte("package sub\n\n// @description Subtracts two numbers\nfunc Sub(a int, b int) int {\n\treturn a - b\n}\n"), 0644))
+
+	visited := []string{}
+	err := docParser.WalkDocForFS(context.Background(), fsys, ".", "", func(pckName string, fileDoc doc.FileDoc) error {
+		visited = append(visited, pckName+":"+fileDoc.Path)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"math:" + filepath.Join("math", "add.go"), "sub:" + filepath.Join("math", "sub", "sub.go")}, visited)
+
+	failure := errors.New("export failure")
+	err = docParser.WalkDocForFS(context.Background(), fsys, ".", "", func(string, doc.FileDoc) error {
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+}
@@ -4,27 +4,50 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/dterbah/zendoc/internal/doc"
 )
 
 /*
 @description Serialize a ProjectDoc into a pretty-printed JSON string
-@param doc doc.ProjectDoc - The project documentation to serialize
+@param projectDoc doc.ProjectDoc - The project documentation to serialize
 @return (string, error) - The resulting JSON string and an error if serialization fails
 @example SerializeToJSON(myDoc)
 @author Dorian TERBAH
 */
-func SerializeToJSON(doc doc.ProjectDoc) (string, error) {
+func SerializeToJSON(projectDoc doc.ProjectDoc) (string, error) {
 	var buf bytes.Buffer
-	enc := json.NewEncoder(&buf)
+	if err := WriteJSON(&buf, projectDoc, true); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+/*
+@description Write a ProjectDoc in JSON, either pretty-printed or on a single line
+@param writer io.Writer - The destination of the JSON
+@param projectDoc doc.ProjectDoc - The project documentation to serialize
+@param pretty bool - Value used to indent the JSON
+@return error - An error if serialization or writing fails
+@example WriteJSON(os.Stdout, myDoc, false)
+@author Dorian TERBAH
+*/
+func WriteJSON(writer io.Writer, projectDoc doc.ProjectDoc, pretty bool) error {
+	if projectDoc.SchemaVersion == "" {
+		projectDoc.SchemaVersion = doc.SCHEMA_VERSION
+	}
+
+	enc := json.NewEncoder(writer)
 	enc.SetEscapeHTML(false)
-	enc.SetIndent("", "  ")
-	err := enc.Encode(doc)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
 
-	if err != nil {
-		return "", fmt.Errorf("error when exporting the documentation in JSON")
+	if err := enc.Encode(projectDoc); err != nil {
+		return fmt.Errorf("error when exporting the documentation in JSON: %w", err)
 	}
 
-	return buf.String(), nil
+	return nil
 }
@@ -1,6 +1,8 @@
 package serializer
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/dterbah/zendoc/internal/doc"
@@ -14,8 +16,8 @@ func TestSerializeToJSON_Success(t *testing.T) {
 				{
 					FileName: "main.go",
 					Path:     "./main.go",
-					Docs: []any{
-						doc.FuncDoc{
+					Docs: []doc.DocItem{
+						&doc.FuncDoc{
 							BaseDoc: doc.BaseDoc{
 								Name:        "MyFunction",
 								Description: "Does something",
@@ -41,3 +43,16 @@ func TestSerializeToJSON_Success(t *testing.T) {
 	assert.Contains(t, result, `"param1"`)
 	assert.Contains(t, result, `"Return value"`)
 }
+
+func TestWriteJSON_Compact(t *testing.T) {
+	projectDoc := doc.ProjectDoc{PackageDocs: map[string][]doc.FileDoc{}}
+
+	var compact bytes.Buffer
+	assert.NoError(t, WriteJSON(&compact, projectDoc, false))
+	assert.Equal(t, 1, strings.Count(compact.String(), "\n"))
+	assert.Contains(t, compact.String(), `"schemaVersion":"`+doc.SCHEMA_VERSION+`"`)
+
+	var pretty bytes.Buffer
+	assert.NoError(t, WriteJSON(&pretty, projectDoc, true))
+	assert.Greater(t, strings.Count(pretty.String(), "\n"), 1)
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+/*
+@description Render the declaration of a function without its body, and without the name of its receiver
+@param fset *token.FileSet - The file set of the parsed file
+@param funcDecl *ast.FuncDecl - The declaration of the function
+@return string - The declaration, such as "func (*DocParser) ParseDocForDir(dirPath string) (*doc.ProjectDoc, error)"
+@author Dorian TERBAH
+*/
+func FuncSignature(fset *token.FileSet, funcDecl *ast.FuncDecl) string {
+	decl := *funcDecl
+	decl.Doc = nil
+	decl.Body = nil
+
+	if funcDecl.Recv != nil {
+		recv := &ast.FieldList{}
+		for _, field := range funcDecl.Recv.List {
+			recv.List = append(recv.List, &ast.Field{Type: field.Type})
+		}
+		decl.Recv = recv
+	}
+
+	return printNode(fset, &decl)
+}
+
+/*
+@description Render the declaration of a type, without its comments
+@param fset *token.FileSet - The file set of the parsed file
+@param typeSpec *ast.TypeSpec - The specification of the type
+@return string - The declaration, such as "type DocParser struct {...}"
+@author Dorian TERBAH
+*/
+func TypeSignature(fset *token.FileSet, typeSpec *ast.TypeSpec) string {
+	spec := *typeSpec
+	spec.Doc = nil
+	spec.Comment = nil
+
+	switch typeExpr := typeSpec.Type.(type) {
+	case *ast.StructType:
+		structType := *typeExpr
+		structType.Fields = withoutComments(typeExpr.Fields)
+		spec.Type = &structType
+	case *ast.InterfaceType:
+		interfaceType := *typeExpr
+		interfaceType.Methods = withoutComments(typeExpr.Methods)
+		spec.Type = &interfaceType
+	}
+
+	return printNode(fset, &ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{&spec}})
+}
+
+// withoutComments copies a list of fields, dropping the comments attached to them
+func withoutComments(fields *ast.FieldList) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+
+	copied := *fields
+	copied.List = []*ast.Field{}
+	for _, field := range fields.List {
+		fieldCopy := *field
+		fieldCopy.Doc = nil
+		fieldCopy.Comment = nil
+		copied.List = append(copied.List, &fieldCopy)
+	}
+	return &copied
+}
+
+// interfaceMethodSignature renders a method of an interface, as written in the interface
+func interfaceMethodSignature(fset *token.FileSet, name string, funcType *ast.FuncType) string {
+	return name + strings.TrimPrefix(printNode(fset, funcType), "func")
+}
+
+func printNode(fset *token.FileSet, node any) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		return ""
+	}
+	return buf.String()
+}
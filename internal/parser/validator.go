@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"regexp"
+
+	"github.com/dterbah/zendoc/config"
+)
+
+/*
+@description Create a documentation parser selecting the files and the functions to document from the documentation configuration
+@param docConfig config.DocConfig - The documentation section of the ZenDoc configuration
+@return DocParser - The parser, printing its progress
+@example NewDocParser(config.DocConfig{IncludePrivate: false})
+@author Dorian TERBAH
+*/
+func NewDocParser(docConfig config.DocConfig) DocParser {
+	return DocParser{
+		FileValidators:     createFilevalidators(docConfig),
+		FunctionValidators: createFunctionsValidators(docConfig),
+	}
+}
+
+func wrapFileValidator(condition bool, wrappedFunc DocParserFileValidator) DocParserFileValidator {
+	return func(filePath string) bool {
+		res := wrappedFunc(filePath)
+		if res {
+			return condition
+		}
+
+		return true
+	}
+}
+
+func wrapFunctionValidator(condition bool, wrappedFunc DocParserFunctionValidator) DocParserFunctionValidator {
+	return func(name string) bool {
+		res := wrappedFunc(name)
+		if res {
+			return condition
+		}
+		return true
+	}
+}
+
+func wrapRegexFileValidator(regex []string) DocParserFileValidator {
+	return func(filePath string) bool {
+		for _, reg := range regex {
+			exp := regexp.MustCompile(reg)
+			// skip invalid regex
+			if exp != nil {
+				return !exp.MatchString(filePath)
+			}
+		}
+		return true
+	}
+}
+
+func createFilevalidators(docConfig config.DocConfig) []DocParserFileValidator {
+	validators := []DocParserFileValidator{}
+
+	validators = append(validators, wrapFileValidator(docConfig.IncludeTests, IsTestFile),
+		wrapFileValidator(docConfig.IncludeMain, IsMainFile),
+		wrapRegexFileValidator(docConfig.ExcludeFiles),
+	)
+
+	return validators
+}
+
+func createFunctionsValidators(docConfig config.DocConfig) []DocParserFunctionValidator {
+	validators := []DocParserFunctionValidator{}
+
+	validators = append(validators, wrapFunctionValidator(docConfig.IncludePrivate, IsPrivateFunction))
+
+	return validators
+}
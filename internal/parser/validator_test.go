@@ -1,22 +1,12 @@
-package generate
+package parser
 
 import (
 	"testing"
 
+	"github.com/dterbah/zendoc/config"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 )
 
-// Mocking dependencies
-type MockDocExporter struct {
-	mock.Mock
-}
-
-func (m *MockDocExporter) Export(doc interface{}) error {
-	args := m.Called(doc)
-	return args.Error(0)
-}
-
 // Test wrapFileValidator
 func TestWrapFileValidator(t *testing.T) {
 	mockValidator := func(filePath string) bool {
@@ -42,3 +32,14 @@ func TestWrapRegexFileValidator(t *testing.T) {
 	// Test file that shouldn't match regex
 	assert.True(t, regexValidator("file.go"))
 }
+
+func TestNewDocParser(t *testing.T) {
+	docParser := NewDocParser(config.DocConfig{IncludeTests: false, IncludeMain: true, ExcludeFiles: []string{`^gen_`}})
+
+	assert.True(t, docParser.isValidateFileForDoc("parser.go"))
+	assert.True(t, docParser.isValidateFileForDoc("main.go"))
+	assert.False(t, docParser.isValidateFileForDoc("parser_test.go"))
+	assert.False(t, docParser.isValidateFileForDoc("gen_parser.go"))
+	assert.True(t, docParser.isValidateFunction("Parse"))
+	assert.False(t, docParser.isValidateFunction("parse"))
+}
@@ -0,0 +1,291 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dterbah/zendoc/internal/doc"
+)
+
+// Version of the serialized index format. Bump it whenever Index changes.
+const INDEX_VERSION = 1
+
+// Name of the index file written next to the exported documentation
+const INDEX_FILE = "search-index.json"
+
+// Ranking weights of the indexed texts
+const (
+	FULL_NAME_WEIGHT   = 20
+	NAME_WEIGHT        = 10
+	QUALIFIER_WEIGHT   = 4
+	MEMBER_WEIGHT      = 3
+	DESCRIPTION_WEIGHT = 1
+)
+
+/*
+@description Struct to represent an indexed symbol, with what is needed to display a search hit
+@author Dorian TERBAH
+@field ID string - The identifier of the symbol (e.g. parser.DocParser.ParseDocForDir)
+@field Name string - The qualified name of the symbol
+@field Kind string - The kind of the symbol (function, struct, interface, interface-method)
+@field Package string - The package declaring the symbol
+@field File string - The path of the file declaring the symbol
+@field Line int - The line of the declaration
+@field Description string - The description of the symbol
+*/
+type Document struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Package     string `json:"package"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Description string `json:"description"`
+}
+
+/*
+@description Struct to represent an inverted index over the symbols of a project. Each term maps to postings made of a document position and a weight, so that the index stays compact once serialized.
+@author Dorian TERBAH
+@field Version int - The version of the index format, see INDEX_VERSION
+@field Documents []Document - The indexed symbols
+@field Terms map[string][][2]int - For each term, the list of [document position, weight] pairs
+*/
+type Index struct {
+	Version   int                 `json:"version"`
+	Documents []Document          `json:"documents"`
+	Terms     map[string][][2]int `json:"terms"`
+}
+
+/*
+@description Struct to represent a search result
+@author Dorian TERBAH
+@field Document Document - The matching symbol
+@field Score int - The relevance of the symbol, higher is better
+*/
+type Hit struct {
+	Document Document `json:"document"`
+	Score    int      `json:"score"`
+}
+
+/*
+@description Split a text into lowercase search terms, breaking identifiers on camelCase, digits and punctuation
+@param text string - The text to split
+@return []string - The terms, in order of appearance
+@example Tokenize("ParseHTMLDoc for_dir") => ["parse", "html", "doc", "for", "dir"]
+@author Dorian TERBAH
+*/
+func Tokenize(text string) []string {
+	terms := []string{}
+	runes := []rune(text)
+	start := -1
+
+	flush := func(end int) {
+		if start >= 0 && end > start {
+			terms = append(terms, strings.ToLower(string(runes[start:end])))
+		}
+		start = -1
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		previous := runes[i-1]
+		switch {
+		// fooBar
+		case unicode.IsUpper(r) && unicode.IsLower(previous):
+			flush(i)
+			start = i
+		// HTMLDoc: the upper case letter followed by a lower case one starts a new term
+		case unicode.IsUpper(r) && unicode.IsUpper(previous) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			flush(i)
+			start = i
+		// v2, 2fa
+		case unicode.IsDigit(r) != unicode.IsDigit(previous):
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(runes))
+
+	return terms
+}
+
+/*
+@description Build the search index of a project: symbol names (camelCase split), descriptions, params, fields and returns
+@param projectDoc doc.ProjectDoc - The project documentation
+@return *Index - The index
+@author Dorian TERBAH
+*/
+func Build(projectDoc doc.ProjectDoc) *Index {
+	index := &Index{
+		Version:   INDEX_VERSION,
+		Documents: []Document{},
+		Terms:     map[string][][2]int{},
+	}
+
+	for _, symbol := range doc.Symbols(projectDoc) {
+		baseDoc := symbol.Item.GetBaseDoc()
+		position := len(index.Documents)
+		index.Documents = append(index.Documents, Document{
+			ID:          symbol.ID,
+			Name:        symbol.Name,
+			Kind:        baseDoc.Type,
+			Package:     symbol.Package,
+			File:        symbol.File.Path,
+			Line:        baseDoc.Position.StartLine,
+			Description: baseDoc.Description,
+		})
+
+		weights := map[string]int{}
+		add := func(text string, weight int) {
+			for _, term := range Tokenize(text) {
+				weights[term] += weight
+			}
+		}
+
+		weights[strings.ToLower(baseDoc.Name)] += FULL_NAME_WEIGHT
+		add(baseDoc.Name, NAME_WEIGHT)
+		add(strings.TrimSuffix(symbol.Name, baseDoc.Name), QUALIFIER_WEIGHT)
+		add(symbol.Package, QUALIFIER_WEIGHT)
+		add(baseDoc.Description, DESCRIPTION_WEIGHT)
+
+		switch item := symbol.Item.(type) {
+		case *doc.FuncDoc:
+			for _, param := range item.Params {
+				add(param.Name, MEMBER_WEIGHT)
+				add(param.Description, DESCRIPTION_WEIGHT)
+			}
+			if item.Return != nil {
+				add(item.Return.Description, DESCRIPTION_WEIGHT)
+			}
+		case *doc.StructDoc:
+			for _, field := range item.Fields {
+				add(field.Name, MEMBER_WEIGHT)
+				add(field.Description, DESCRIPTION_WEIGHT)
+			}
+		case *doc.InterfaceDoc:
+			for _, method := range item.Methods {
+				add(method.Name, MEMBER_WEIGHT)
+			}
+		}
+
+		for term, weight := range weights {
+			index.Terms[term] = append(index.Terms[term], [2]int{position, weight})
+		}
+	}
+
+	return index
+}
+
+/*
+@description Search the symbols matching every term of a query. A term matches the indexed terms it is equal to, or, with half the weight, the ones it is a prefix of.
+@param query string - The query, split like the indexed texts
+@param limit int - The maximum number of hits, 0 for no limit
+@return []Hit - The hits, sorted by decreasing score then by identifier
+@example index.Search("parse dir", 10) => [{Document: {ID: "parser.DocParser.ParseDocForDir", ...}, Score: 42}]
+@author Dorian TERBAH
+*/
+func (index *Index) Search(query string, limit int) []Hit {
+	queryTerms := Tokenize(query)
+	if len(queryTerms) == 0 {
+		return []Hit{}
+	}
+
+	var scores map[int]int
+	for _, queryTerm := range queryTerms {
+		termScores := map[int]int{}
+		for term, postings := range index.Terms {
+			weightFactor := 0
+			switch {
+			case term == queryTerm:
+				weightFactor = 2
+			case strings.HasPrefix(term, queryTerm):
+				weightFactor = 1
+			default:
+				continue
+			}
+
+			for _, posting := range postings {
+				termScores[posting[0]] += posting[1] * weightFactor
+			}
+		}
+
+		// a symbol has to match every term of the query
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for position := range scores {
+			if termScore, ok := termScores[position]; ok {
+				scores[position] += termScore
+			} else {
+				delete(scores, position)
+			}
+		}
+	}
+
+	hits := []Hit{}
+	for position, score := range scores {
+		hits = append(hits, Hit{Document: index.Documents[position], Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Document.ID < hits[j].Document.ID
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+/*
+@description Serialize the index in a compact JSON form
+@return ([]byte, error) - The JSON content and an error if the serialization fails
+@author Dorian TERBAH
+*/
+func (index *Index) MarshalCompact() ([]byte, error) {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return nil, fmt.Errorf("error when serializing the search index: %w", err)
+	}
+	return data, nil
+}
+
+/*
+@description Load a serialized search index
+@param reader io.Reader - The JSON content of the index
+@return (*Index, error) - The index and an error if the content is invalid, uses another format version or has a posting outside of its documents
+@author Dorian TERBAH
+*/
+func Load(reader io.Reader) (*Index, error) {
+	var index Index
+	if err := json.NewDecoder(reader).Decode(&index); err != nil {
+		return nil, fmt.Errorf("error when reading the search index: %w", err)
+	}
+	if index.Version != INDEX_VERSION {
+		return nil, fmt.Errorf("unsupported search index version %d, expected %d", index.Version, INDEX_VERSION)
+	}
+	for term, postings := range index.Terms {
+		for _, posting := range postings {
+			if posting[0] < 0 || posting[0] >= len(index.Documents) {
+				return nil, fmt.Errorf("invalid search index: the term %s points to the document %d out of %d", term, posting[0], len(index.Documents))
+			}
+		}
+	}
+
+	return &index, nil
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func hitIDs(hits []Hit) []string {
+	ids := []string{}
+	for _, hit := range hits {
+		ids = append(ids, hit.Document.ID)
+	}
+	return ids
+}
+
+func TestTokenize(t *testing.T) {
+	tests := map[string][]string{
+		"ParseDocForDir":       {"parse", "doc", "for", "dir"},
+		"HTMLExporter":         {"html", "exporter"},
+		"parseHTML":            {"parse", "html"},
+		"file_validators v2":   {"file", "validators", "v", "2"},
+		"The root | path.":     {"the", "root", "path"},
+		"":                     {},
+		"DocParser.ParseDoc()": {"doc", "parser", "parse", "doc"},
+	}
+
+	for text, expected := range tests {
+		assert.Equal(t, expected, Tokenize(text), text)
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	index := Build(doctest.Sample())
+
+	tests := map[string]struct {
+		query    string
+		expected []string
+	}{
+		"camel case query": {query: "ParseDocForDir", expected: []string{"parser.DocParser.ParseDocForDir"}},
+		"name first":       {query: "parser", expected: []string{"parser.DocParser", "parser.DocParser.ParseDocForDir", "parser.getPackageName"}},
+		"prefix":           {query: "html", expected: []string{"export.HTMLExporter", "export.HTMLExporter.Export"}},
+		"lowercase name":   {query: "parsedoc", expected: []string{"parser.DocParser.ParseDocForDir"}},
+		"description":      {query: "recursively", expected: []string{"parser.DocParser.ParseDocForDir"}},
+		"param":            {query: "dirPath", expected: []string{"parser.DocParser.ParseDocForDir"}},
+		"field":            {query: "validators", expected: []string{"parser.DocParser"}},
+		"every term":       {query: "static parser", expected: []string{}},
+		"empty":            {query: "  ", expected: []string{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, hitIDs(index.Search(test.query, 0)))
+		})
+	}
+
+	hits := index.Search("doc", 1)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, "internal/export/export.go", hits[0].Document.File)
+}
+
+func TestIndex_RoundTrip(t *testing.T) {
+	index := Build(doctest.Sample())
+
+	data, err := index.MarshalCompact()
+	assert.NoError(t, err)
+
+	loaded, err := Load(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, index.Search("parse", 0), loaded.Search("parse", 0))
+
+	_, err = Load(bytes.NewReader([]byte(`{"version": 99}`)))
+	assert.ErrorContains(t, err, "unsupported search index version")
+
+	_, err = Load(bytes.NewReader([]byte(fmt.Sprintf(`{"version": %d, "documents": [{"id": "parser.Parse"}], "terms": {"parse": [[0, 1], [1, 1]]}}`, INDEX_VERSION))))
+	assert.ErrorContains(t, err, "the term parse points to the document 1 out of 1")
+}
@@ -0,0 +1,136 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+@description Struct to represent a semantic version. The "v" prefix, a missing minor or patch number and the build metadata are accepted when parsing.
+@author Dorian TERBAH
+@field Major int - The major number, bumped on breaking changes
+@field Minor int - The minor number, bumped on compatible features
+@field Patch int - The patch number, bumped on fixes
+@field Prerelease string - The pre-release identifiers, after the "-" (e.g. "rc.1")
+*/
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+/*
+@description Parse a semantic version
+@param value string - The version, such as "1.2.3", "v1.2.3-rc.1" or "1.0"
+@return (Version, error) - The parsed version and an error if it is not a semantic version
+@example Parse("v1.2.3-rc.1") => Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}
+@author Dorian TERBAH
+*/
+func Parse(value string) (Version, error) {
+	version := Version{}
+
+	text := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if index := strings.Index(text, "+"); index >= 0 {
+		text = text[:index]
+	}
+	if index := strings.Index(text, "-"); index >= 0 {
+		version.Prerelease = text[index+1:]
+		text = text[:index]
+		if version.Prerelease == "" {
+			return Version{}, fmt.Errorf("invalid version \"%s\": empty pre-release", value)
+		}
+	}
+
+	parts := strings.Split(text, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version \"%s\": expected at most 3 numbers", value)
+	}
+
+	numbers := []*int{&version.Major, &version.Minor, &version.Patch}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return Version{}, fmt.Errorf("invalid version \"%s\": \"%s\" is not a number", value, part)
+		}
+		*numbers[i] = number
+	}
+
+	return version, nil
+}
+
+/*
+@description Compare two versions, following the precedence rules of semantic versioning
+@param other Version - The version to compare with
+@return int - A negative number if the version is lower than the other one, 0 if they are equal, a positive number otherwise
+@example Compare(Parse("1.2.0"), Parse("1.10.0")) => -1
+@author Dorian TERBAH
+*/
+func (version Version) Compare(other Version) int {
+	for _, diff := range []int{version.Major - other.Major, version.Minor - other.Minor, version.Patch - other.Patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+
+	// a pre-release has a lower precedence than the release
+	switch {
+	case version.Prerelease == other.Prerelease:
+		return 0
+	case version.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+
+	identifiers := strings.Split(version.Prerelease, ".")
+	otherIdentifiers := strings.Split(other.Prerelease, ".")
+	for i := 0; i < len(identifiers) && i < len(otherIdentifiers); i++ {
+		if result := compareIdentifiers(identifiers[i], otherIdentifiers[i]); result != 0 {
+			return result
+		}
+	}
+
+	return sign(len(identifiers) - len(otherIdentifiers))
+}
+
+/*
+@description Render the version as major.minor.patch, followed by its pre-release
+@return string - The version
+@author Dorian TERBAH
+*/
+func (version Version) String() string {
+	text := fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+	if version.Prerelease != "" {
+		text += "-" + version.Prerelease
+	}
+	return text
+}
+
+// compareIdentifiers compares numeric identifiers numerically, and others in ASCII order, numeric ones being lower
+func compareIdentifiers(identifier, other string) int {
+	number, err := strconv.Atoi(identifier)
+	otherNumber, otherErr := strconv.Atoi(other)
+
+	switch {
+	case err == nil && otherErr == nil:
+		return sign(number - otherNumber)
+	case err == nil:
+		return -1
+	case otherErr == nil:
+		return 1
+	}
+
+	return strings.Compare(identifier, other)
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,45 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]Version{
+		"1.2.3":             {Major: 1, Minor: 2, Patch: 3},
+		"v1.2.3":            {Major: 1, Minor: 2, Patch: 3},
+		"1.0":               {Major: 1},
+		"2":                 {Major: 2},
+		"1.2.3-rc.1":        {Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"},
+		"1.2.3-rc.1+build5": {Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"},
+	}
+
+	for value, expected := range tests {
+		version, err := Parse(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, version, value)
+	}
+
+	for _, value := range []string{"", "latest", "1.2.3.4", "1.x", "1.2.3-"} {
+		_, err := Parse(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []string{"0.9.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.2.0", "1.10.0", "2.0.0"}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		lower, _ := Parse(ordered[i])
+		higher, _ := Parse(ordered[i+1])
+		assert.Equal(t, -1, lower.Compare(higher), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, higher.Compare(lower), "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	a, _ := Parse("v1.0")
+	b, _ := Parse("1.0.0")
+	assert.Equal(t, 0, a.Compare(b))
+	assert.Equal(t, "1.0.0-rc.1", Version{Major: 1, Prerelease: "rc.1"}.String())
+}
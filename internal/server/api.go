@@ -0,0 +1,217 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dterbah/zendoc/internal/doc"
+)
+
+const API_ROUTE = "/api"
+
+/*
+@description Struct to represent a package in the list returned by the API
+@author Dorian TERBAH
+@field Name string - The name of the package
+@field Files int - The number of documented files
+@field Symbols int - The number of documented symbols, interface methods included
+*/
+type PackageSummary struct {
+	Name    string `json:"name"`
+	Files   int    `json:"files"`
+	Symbols int    `json:"symbols"`
+}
+
+/*
+@description Struct to represent a package with its documented files, returned by the API
+@author Dorian TERBAH
+@field Name string - The name of the package
+@field Files []doc.FileDoc - The documented files of the package
+*/
+type PackageResponse struct {
+	Name  string        `json:"name"`
+	Files []doc.FileDoc `json:"files"`
+}
+
+/*
+@description Struct to represent a symbol in the search results of the API
+@author Dorian TERBAH
+@field ID string - The identifier of the symbol, usable with /api/symbols/{id}
+@field Name string - The qualified name of the symbol
+@field Kind string - The kind of the symbol (function, struct, interface, interface-method)
+@field Package string - The package declaring the symbol
+@field File string - The path of the file declaring the symbol
+@field Line int - The line of the declaration
+@field Description string - The description of the symbol
+@field Deprecated string - The deprecation message, if the symbol is deprecated
+*/
+type SymbolSummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Package     string `json:"package"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Description string `json:"description"`
+	Deprecated  string `json:"deprecated,omitempty"`
+}
+
+/*
+@description Struct to represent the whole documentation of a symbol, returned by the API
+@author Dorian TERBAH
+@field ID string - The identifier of the symbol
+@field Package string - The package declaring the symbol
+@field File string - The path of the file declaring the symbol
+@field Item doc.DocItem - The documented item
+*/
+type SymbolResponse struct {
+	ID      string      `json:"id"`
+	Package string      `json:"package"`
+	File    string      `json:"file"`
+	Item    doc.DocItem `json:"item"`
+}
+
+// apiRoutes registers the read-only query API on the mux
+func (server *Server) apiRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+API_ROUTE+"/packages", server.listPackages)
+	mux.HandleFunc("GET "+API_ROUTE+"/packages/{path...}", server.getPackage)
+	mux.HandleFunc("GET "+API_ROUTE+"/symbols", server.listSymbols)
+	mux.HandleFunc("GET "+API_ROUTE+"/symbols/{id}", server.getSymbol)
+}
+
+func (server *Server) listPackages(writer http.ResponseWriter, request *http.Request) {
+	projectDoc, symbols, ok := server.snapshot(writer)
+	if !ok {
+		return
+	}
+
+	counts := map[string]int{}
+	for _, symbol := range symbols {
+		counts[symbol.Package]++
+	}
+
+	packages := []PackageSummary{}
+	for pckName, files := range projectDoc.PackageDocs {
+		packages = append(packages, PackageSummary{Name: pckName, Files: len(files), Symbols: counts[pckName]})
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	writeJSON(writer, http.StatusOK, packages)
+}
+
+func (server *Server) getPackage(writer http.ResponseWriter, request *http.Request) {
+	projectDoc, _, ok := server.snapshot(writer)
+	if !ok {
+		return
+	}
+
+	pckName := request.PathValue("path")
+	files, found := projectDoc.PackageDocs[pckName]
+	if !found {
+		writeError(writer, http.StatusNotFound, fmt.Sprintf("unknown package %s", pckName))
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, PackageResponse{Name: pckName, Files: files})
+}
+
+func (server *Server) listSymbols(writer http.ResponseWriter, request *http.Request) {
+	_, symbols, ok := server.snapshot(writer)
+	if !ok {
+		return
+	}
+
+	query := strings.ToLower(request.URL.Query().Get("q"))
+	kind := request.URL.Query().Get("kind")
+
+	var deprecated *bool
+	if value := request.URL.Query().Get("deprecated"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Sprintf("invalid deprecated filter \"%s\", expected true or false", value))
+			return
+		}
+		deprecated = &parsed
+	}
+
+	results := []SymbolSummary{}
+	for _, symbol := range symbols {
+		baseDoc := symbol.Item.GetBaseDoc()
+
+		if query != "" && !strings.Contains(strings.ToLower(symbol.ID), query) && !strings.Contains(strings.ToLower(baseDoc.Description), query) {
+			continue
+		}
+		if kind != "" && baseDoc.Type != kind {
+			continue
+		}
+		if deprecated != nil && (baseDoc.Deprecated != "") != *deprecated {
+			continue
+		}
+
+		results = append(results, SymbolSummary{
+			ID:          symbol.ID,
+			Name:        symbol.Name,
+			Kind:        baseDoc.Type,
+			Package:     symbol.Package,
+			File:        symbol.File.Path,
+			Line:        baseDoc.Position.StartLine,
+			Description: baseDoc.Description,
+			Deprecated:  baseDoc.Deprecated,
+		})
+	}
+
+	writeJSON(writer, http.StatusOK, results)
+}
+
+func (server *Server) getSymbol(writer http.ResponseWriter, request *http.Request) {
+	_, symbols, ok := server.snapshot(writer)
+	if !ok {
+		return
+	}
+
+	id := request.PathValue("id")
+	symbol, found := doc.FindSymbol(symbols, id)
+	if !found {
+		writeError(writer, http.StatusNotFound, fmt.Sprintf("unknown symbol %s", id))
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, SymbolResponse{
+		ID:      symbol.ID,
+		Package: symbol.Package,
+		File:    symbol.File.Path,
+		Item:    symbol.Item,
+	})
+}
+
+// snapshot retrieves the served documentation, answering an error when it is not generated yet
+func (server *Server) snapshot(writer http.ResponseWriter) (doc.ProjectDoc, []doc.Symbol, bool) {
+	server.mutex.RLock()
+	defer server.mutex.RUnlock()
+
+	if server.projectDoc == nil {
+		writeError(writer, http.StatusServiceUnavailable, "documentation not generated yet")
+		return doc.ProjectDoc{}, nil, false
+	}
+
+	return *server.projectDoc, server.symbols, true
+}
+
+func writeJSON(writer http.ResponseWriter, status int, value any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+}
+
+func writeError(writer http.ResponseWriter, status int, message string) {
+	writeJSON(writer, status, map[string]string{"error": message})
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/export/site"
+	"github.com/stretchr/testify/assert"
+)
+
+func getJSON(t *testing.T, url string, value any) int {
+	response, err := http.Get(url)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(value))
+	return response.StatusCode
+}
+
+func symbolIDs(symbols []SymbolSummary) []string {
+	ids := []string{}
+	for _, symbol := range symbols {
+		ids = append(ids, symbol.ID)
+	}
+	return ids
+}
+
+func TestAPI_Packages(t *testing.T) {
+	server := NewServer(site.Options{Title: "zendoc", Version: "1.0.0"})
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	var apiError map[string]string
+	assert.Equal(t, http.StatusServiceUnavailable, getJSON(t, httpServer.URL+"/api/packages", &apiError))
+
+	assert.NoError(t, server.Export(context.Background(), doctest.Sample()))
+
+	var packages []PackageSummary
+	assert.Equal(t, http.StatusOK, getJSON(t, httpServer.URL+"/api/packages", &packages))
+	assert.Equal(t, []PackageSummary{
+		{Name: "export", Files: 2, Symbols: 4},
+		{Name: "parser", Files: 1, Symbols: 3},
+		{Name: "system", Files: 1, Symbols: 2},
+	}, packages)
+
+	var pck struct {
+		Name  string `json:"name"`
+		Files []struct {
+			Path string `json:"path"`
+		} `json:"files"`
+	}
+	assert.Equal(t, http.StatusOK, getJSON(t, httpServer.URL+"/api/packages/parser", &pck))
+	assert.Equal(t, "parser", pck.Name)
+	assert.Equal(t, "internal/parser/parser.go", pck.Files[0].Path)
+
+	assert.Equal(t, http.StatusNotFound, getJSON(t, httpServer.URL+"/api/packages/unknown", &apiError))
+	assert.Contains(t, apiError["error"], "unknown package")
+}
+
+func TestAPI_Symbols(t *testing.T) {
+	server := NewServer(site.Options{Title: "zendoc", Version: "1.0.0"})
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+	assert.NoError(t, server.Export(context.Background(), doctest.Sample()))
+
+	tests := map[string]struct {
+		query    string
+		expected []string
+	}{
+		"all":            {query: "", expected: []string{"export.DocExporter", "export.DocExporter.Export", "export.HTMLExporter", "export.HTMLExporter.Export", "parser.DocParser", "parser.DocParser.ParseDocForDir", "parser.getPackageName", "system.CommandRunner", "system.CommandRunner.Execute"}},
+		"name query":     {query: "?q=docparser", expected: []string{"parser.DocParser", "parser.DocParser.ParseDocForDir"}},
+		"description":    {query: "?q=directory", expected: []string{"parser.DocParser.ParseDocForDir"}},
+		"kind":           {query: "?kind=interface-method", expected: []string{"export.DocExporter.Export", "system.CommandRunner.Execute"}},
+		"deprecated":     {query: "?deprecated=true", expected: []string{"parser.DocParser.ParseDocForDir", "parser.getPackageName"}},
+		"not deprecated": {query: "?deprecated=false&kind=function", expected: []string{"export.HTMLExporter.Export"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var symbols []SymbolSummary
+			assert.Equal(t, http.StatusOK, getJSON(t, httpServer.URL+"/api/symbols"+test.query, &symbols))
+			assert.Equal(t, test.expected, symbolIDs(symbols))
+		})
+	}
+
+	var symbols []SymbolSummary
+	getJSON(t, httpServer.URL+"/api/symbols?q=ParseDocForDir", &symbols)
+	assert.Equal(t, SymbolSummary{
+		ID:          "parser.DocParser.ParseDocForDir",
+		Name:        "DocParser.ParseDocForDir",
+		Kind:        doc.FUNCTION_TYPE,
+		Package:     "parser",
+		File:        "internal/parser/parser.go",
+		Line:        74,
+		Description: "Recursively parse a directory",
+		Deprecated:  "Use ParseFS",
+	}, symbols[0])
+
+	var apiError map[string]string
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, httpServer.URL+"/api/symbols?deprecated=maybe", &apiError))
+}
+
+func TestAPI_Symbol(t *testing.T) {
+	server := NewServer(site.Options{Title: "zendoc", Version: "1.0.0"})
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+	assert.NoError(t, server.Export(context.Background(), doctest.Sample()))
+
+	var symbol struct {
+		ID   string      `json:"id"`
+		File string      `json:"file"`
+		Item doc.FuncDoc `json:"item"`
+	}
+	assert.Equal(t, http.StatusOK, getJSON(t, httpServer.URL+"/api/symbols/parser.DocParser.ParseDocForDir", &symbol))
+	assert.Equal(t, "parser.DocParser.ParseDocForDir", symbol.ID)
+	assert.Equal(t, "internal/parser/parser.go", symbol.File)
+	assert.Equal(t, "dirPath", symbol.Item.Params[0].Name)
+
+	var apiError map[string]string
+	assert.Equal(t, http.StatusNotFound, getJSON(t, httpServer.URL+"/api/symbols/parser.Unknown", &apiError))
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/site"
+	"github.com/dterbah/zendoc/internal/parser/serializer"
+	"github.com/dterbah/zendoc/internal/search"
+	"github.com/fatih/color"
+)
+
+const DOC_JSON_ROUTE = "/doc.json"
+const EVENTS_ROUTE = "/events"
+
+// script injected in every page, reloading it when the documentation changes
+const liveReloadScript = `<script>new EventSource("` + EVENTS_ROUTE + `").addEventListener("reload", function () { location.reload(); });</script>`
+
+/*
+@description Struct that serves the documentation of a project over HTTP: the rendered HTML site, the raw JSON documentation, a read-only query API and a Server-Sent Events stream pushing a reload to the browsers when the documentation changes. It implements the DocExporter interface, so that a FileWatcher can refresh it.
+@author Dorian TERBAH
+@field Options site.Options - The options used to render the site
+*/
+type Server struct {
+	Options site.Options
+
+	mutex      sync.RWMutex
+	files      map[string][]byte
+	docJSON    []byte
+	projectDoc *doc.ProjectDoc
+	symbols    []doc.Symbol
+	modTime    time.Time
+	clients    map[chan struct{}]struct{}
+	clientMu   sync.Mutex
+}
+
+/*
+@description Create a server with nothing to serve until the first export
+@param options site.Options - The options used to render the site
+@return *Server - The server
+@author Dorian TERBAH
+*/
+func NewServer(options site.Options) *Server {
+	return &Server{
+		Options: options,
+		files:   map[string][]byte{},
+		clients: map[chan struct{}]struct{}{},
+	}
+}
+
+/*
+@description Render the documentation, replace the served content and ask the connected browsers to reload
+@param ctx context.Context - The context of the export
+@param projectDoc doc.ProjectDoc - The documentation to serve
+@return error - An error if the site or the JSON documentation cannot be rendered
+@author Dorian TERBAH
+*/
+func (server *Server) Export(ctx context.Context, projectDoc doc.ProjectDoc) error {
+	files, err := site.Build(projectDoc, server.Options)
+	if err != nil {
+		return fmt.Errorf("error when rendering the HTML documentation: %w", err)
+	}
+
+	versions, err := site.BuildVersions([]string{server.Options.Version}, server.Options.Version)
+	if err != nil {
+		return err
+	}
+	files[site.VERSIONS_FILE] = versions
+
+	index, err := search.Build(projectDoc).MarshalCompact()
+	if err != nil {
+		return err
+	}
+	files[search.INDEX_FILE] = index
+
+	docJSON, err := serializer.SerializeToJSON(projectDoc)
+	if err != nil {
+		return err
+	}
+
+	server.mutex.Lock()
+	server.files = files
+	server.docJSON = []byte(docJSON)
+	server.projectDoc = &projectDoc
+	server.symbols = doc.Symbols(projectDoc)
+	server.modTime = time.Now()
+	server.mutex.Unlock()
+
+	server.broadcastReload()
+	color.Green("Documentation refreshed")
+	return nil
+}
+
+/*
+@description Build the HTTP handler of the server
+@return http.Handler - The handler serving the site, the JSON documentation, the query API and the reload events
+@author Dorian TERBAH
+*/
+func (server *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+DOC_JSON_ROUTE, server.serveDocJSON)
+	mux.HandleFunc("GET "+EVENTS_ROUTE, server.serveEvents)
+	server.apiRoutes(mux)
+	mux.HandleFunc("GET /", server.serveSite)
+	return mux
+}
+
+func (server *Server) serveSite(writer http.ResponseWriter, request *http.Request) {
+	name := strings.TrimPrefix(path.Clean(request.URL.Path), "/")
+	if name == "" {
+		name = site.INDEX_PAGE
+	}
+
+	server.mutex.RLock()
+	content, ok := server.files[name]
+	modTime := server.modTime
+	server.mutex.RUnlock()
+
+	if !ok {
+		http.NotFound(writer, request)
+		return
+	}
+
+	if path.Ext(name) == ".html" {
+		content = bytes.Replace(content, []byte("</body>"), []byte(liveReloadScript+"\n</body>"), 1)
+	}
+
+	http.ServeContent(writer, request, name, modTime, bytes.NewReader(content))
+}
+
+func (server *Server) serveDocJSON(writer http.ResponseWriter, request *http.Request) {
+	server.mutex.RLock()
+	content := server.docJSON
+	modTime := server.modTime
+	server.mutex.RUnlock()
+
+	if content == nil {
+		http.Error(writer, "documentation not generated yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	http.ServeContent(writer, request, DOC_JSON_ROUTE, modTime, bytes.NewReader(content))
+}
+
+// serveEvents keeps the connection open and sends a "reload" event after each export
+func (server *Server) serveEvents(writer http.ResponseWriter, request *http.Request) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		http.Error(writer, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+
+	reload := make(chan struct{}, 1)
+	server.clientMu.Lock()
+	server.clients[reload] = struct{}{}
+	server.clientMu.Unlock()
+
+	defer func() {
+		server.clientMu.Lock()
+		delete(server.clients, reload)
+		server.clientMu.Unlock()
+	}()
+
+	fmt.Fprint(writer, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-reload:
+			fmt.Fprint(writer, "event: reload\ndata: {}\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func (server *Server) broadcastReload() {
+	server.clientMu.Lock()
+	defer server.clientMu.Unlock()
+
+	for client := range server.clients {
+		// a pending reload is enough, the browser reloads only once
+		select {
+		case client <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/dterbah/zendoc/internal/export/site"
+	"github.com/dterbah/zendoc/internal/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func get(t *testing.T, url string) (int, string, string) {
+	response, err := http.Get(url)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	return response.StatusCode, response.Header.Get("Content-Type"), string(body)
+}
+
+func TestServer_ServesSiteAndJSON(t *testing.T) {
+	server := NewServer(site.Options{Title: "zendoc", Version: "1.0.0"})
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	status, _, _ := get(t, httpServer.URL+DOC_JSON_ROUTE)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	assert.NoError(t, server.Export(context.Background(), doctest.Project(&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "DocParser", Type: doc.STRUCT_TYPE, Description: "The parser"}})))
+
+	status, contentType, body := get(t, httpServer.URL+"/")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, contentType, "text/html")
+	assert.Contains(t, body, "parser")
+	assert.Contains(t, body, liveReloadScript)
+
+	status, _, body = get(t, httpServer.URL+"/symbols/parser/DocParser.html")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, "The parser")
+
+	status, _, body = get(t, httpServer.URL+"/versions.js")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"1.0.0"`)
+
+	status, _, body = get(t, httpServer.URL+"/"+search.INDEX_FILE)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"parser.DocParser"`)
+
+	status, contentType, body = get(t, httpServer.URL+DOC_JSON_ROUTE)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "application/json", contentType)
+	loaded, err := doc.LoadProjectDoc(strings.NewReader(body))
+	assert.NoError(t, err)
+	assert.Contains(t, loaded.PackageDocs, "parser")
+
+	status, _, _ = get(t, httpServer.URL+"/missing.html")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestServer_PushesReloadEvents(t *testing.T) {
+	server := NewServer(site.Options{Title: "zendoc", Version: "1.0.0"})
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+EVENTS_ROUTE, nil)
+	assert.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(response.Body)
+	line, err := reader.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, ": connected\n", line)
+
+	assert.NoError(t, server.Export(context.Background(), doctest.Project(&doc.StructDoc{BaseDoc: doc.BaseDoc{Name: "DocParser", Type: doc.STRUCT_TYPE, Description: "The parser"}})))
+
+	for {
+		line, err = reader.ReadString('\n')
+		assert.NoError(t, err)
+		if strings.HasPrefix(line, "event:") {
+			break
+		}
+	}
+	assert.Equal(t, "event: reload\n", line)
+}
@@ -1,17 +1,31 @@
 package system
 
+import "context"
+
 /*
 @description Interface for running system commands
 @author Dorian TERBAH
 */
 type CommandRunner interface {
 	/*
-	   @description Executes a system command in the specified directory.
+	   @description Executes a system command in the specified directory. The command is killed when the context is done.
+	   @param ctx context.Context - The context of the command, which may carry a timeout.
 	   @param dir string - The directory where the command will be executed.
 	   @param name string - The name of the command to execute.
 	   @param arg ...string - Additional arguments to pass to the command.
 	   @author Dorian TERBAH
 	   @return ([]byte, error) - The output from the executed command, and an error if the command fails.
 	*/
-	Execute(dir string, name string, arg ...string) ([]byte, error)
+	Execute(ctx context.Context, dir string, name string, arg ...string) ([]byte, error)
+	/*
+	   @description Executes a system command in the specified directory, writing the given input to its standard input. The standard error of the command is forwarded to the standard error of zendoc. The command is killed when the context is done.
+	   @param ctx context.Context - The context of the command, which may carry a timeout.
+	   @param dir string - The directory where the command will be executed.
+	   @param input []byte - The data written to the standard input of the command.
+	   @param name string - The name of the command to execute.
+	   @param arg ...string - Additional arguments to pass to the command.
+	   @author Dorian TERBAH
+	   @return ([]byte, error) - The standard output of the executed command, and an error if the command fails.
+	*/
+	ExecuteWithInput(ctx context.Context, dir string, input []byte, name string, arg ...string) ([]byte, error)
 }
@@ -1,6 +1,9 @@
 package system
 
-import "io/fs"
+import (
+	"io"
+	"io/fs"
+)
 
 /*
 @description Interface for file system operations
@@ -15,6 +18,22 @@ type FileSystem interface {
 	*/
 	FileExists(path string) bool
 
+	/*
+	   @description Reads the whole content of a file.
+	   @param path string - The path of the file to read.
+	   @author Dorian TERBAH
+	   @return ([]byte, error) - The content of the file and an error if it could not be read, matching fs.ErrNotExist when the file does not exist.
+	*/
+	ReadFile(path string) ([]byte, error)
+
+	/*
+	   @description Opens a file to read its content progressively. The file must be closed once read.
+	   @param path string - The path of the file to open.
+	   @author Dorian TERBAH
+	   @return (fs.File, error) - The opened file and an error if it could not be opened, matching fs.ErrNotExist when the file does not exist.
+	*/
+	Open(path string) (fs.File, error)
+
 	/*
 	   @description Writes data to a file at the specified path with the given permissions.
 	   @param path string - The path where the file should be written.
@@ -25,6 +44,15 @@ type FileSystem interface {
 	*/
 	WriteFile(path string, data []byte, perm uint32) error
 
+	/*
+	   @description Creates or truncates a file, to write its content progressively. The content is complete once the writer is closed.
+	   @param path string - The path of the file.
+	   @param perm uint32 - The file permissions to set.
+	   @author Dorian TERBAH
+	   @return (io.WriteCloser, error) - The writer of the file and an error if the file could not be created.
+	*/
+	Create(path string, perm uint32) (io.WriteCloser, error)
+
 	/*
 	   @description Creates a directory and all necessary parent directories with the specified permissions.
 	   @param path string - The path of the directory to create.
@@ -42,4 +70,11 @@ type FileSystem interface {
 	   @return error - An error if the file or directory could not be renamed.
 	*/
 	Rename(oldPath, newPath string) error
+	/*
+	   @description Removes a file or a directory and everything it contains. Removing a path that does not exist is not an error.
+	   @param path string - The path of the file or directory to remove.
+	   @author Dorian TERBAH
+	   @return error - An error if the path could not be removed.
+	*/
+	RemoveAll(path string) error
 }
@@ -0,0 +1,173 @@
+package system
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing/fstest"
+	"time"
+)
+
+/*
+@description File system kept in memory, used to parse sources that are not on the disk (git objects, archives, test fixtures) and to capture the files written by the exporters. It implements both FileSystem and fs.FS. Paths are slash-separated and relative to the root of the file system: the write methods also accept the OS paths, while the read methods follow the fs.FS contract.
+@author Dorian TERBAH
+*/
+type MemoryFileSystem struct {
+	mutex sync.RWMutex
+	files fstest.MapFS
+}
+
+/*
+@description Create an empty in-memory file system
+@return *MemoryFileSystem - The file system
+@author Dorian TERBAH
+*/
+func NewMemoryFileSystem() *MemoryFileSystem {
+	return &MemoryFileSystem{files: fstest.MapFS{}}
+}
+
+/*
+@description Open a file or a directory, following the fs.FS contract: the path must be clean and relative, as the ones joined from a relative directory
+@param name string - The path of the file
+@return (fs.File, error) - The opened file and an error if it does not exist
+@author Dorian TERBAH
+*/
+func (memoryFs *MemoryFileSystem) Open(name string) (fs.File, error) {
+	memoryFs.mutex.RLock()
+	defer memoryFs.mutex.RUnlock()
+	return memoryFs.files.Open(readPath(name))
+}
+
+/*
+@description Read the content of a file
+@param name string - The path of the file, clean and relative as for Open
+@return ([]byte, error) - A copy of the content and an error if the file does not exist
+@author Dorian TERBAH
+*/
+func (memoryFs *MemoryFileSystem) ReadFile(name string) ([]byte, error) {
+	memoryFs.mutex.RLock()
+	defer memoryFs.mutex.RUnlock()
+	return memoryFs.files.ReadFile(readPath(name))
+}
+
+func (memoryFs *MemoryFileSystem) FileExists(filePath string) bool {
+	memoryFs.mutex.RLock()
+	defer memoryFs.mutex.RUnlock()
+	_, err := memoryFs.files.Stat(memoryPath(filePath))
+	return err == nil
+}
+
+func (memoryFs *MemoryFileSystem) WriteFile(filePath string, data []byte, perm uint32) error {
+	name := memoryPath(filePath)
+	if name == "." {
+		return &fs.PathError{Op: "write", Path: filePath, Err: fs.ErrInvalid}
+	}
+
+	memoryFs.mutex.Lock()
+	defer memoryFs.mutex.Unlock()
+	if file, ok := memoryFs.files[name]; ok && file.Mode.IsDir() {
+		return &fs.PathError{Op: "write", Path: filePath, Err: fmt.Errorf("is a directory")}
+	}
+
+	memoryFs.files[name] = &fstest.MapFile{
+		Data:    append([]byte{}, data...),
+		Mode:    fs.FileMode(perm),
+		ModTime: time.Now(),
+	}
+	return nil
+}
+
+func (memoryFs *MemoryFileSystem) Create(filePath string, perm uint32) (io.WriteCloser, error) {
+	if err := memoryFs.WriteFile(filePath, []byte{}, perm); err != nil {
+		return nil, err
+	}
+	return &memoryFile{fileSystem: memoryFs, path: filePath, perm: perm}, nil
+}
+
+func (memoryFs *MemoryFileSystem) MkdirAll(filePath string, perm fs.FileMode) error {
+	name := memoryPath(filePath)
+	if name == "." {
+		return nil
+	}
+
+	memoryFs.mutex.Lock()
+	defer memoryFs.mutex.Unlock()
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		if file, ok := memoryFs.files[dir]; ok && !file.Mode.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: filePath, Err: fmt.Errorf("not a directory")}
+		}
+		if _, ok := memoryFs.files[dir]; !ok {
+			memoryFs.files[dir] = &fstest.MapFile{Mode: fs.ModeDir | perm, ModTime: time.Now()}
+		}
+	}
+	return nil
+}
+
+func (memoryFs *MemoryFileSystem) Rename(oldPath, newPath string) error {
+	oldName, newName := memoryPath(oldPath), memoryPath(newPath)
+
+	memoryFs.mutex.Lock()
+	defer memoryFs.mutex.Unlock()
+
+	// a directory is renamed with all the files it contains
+	moved := map[string]*fstest.MapFile{}
+	for name, file := range memoryFs.files {
+		if name == oldName || strings.HasPrefix(name, oldName+"/") {
+			moved[newName+strings.TrimPrefix(name, oldName)] = file
+			delete(memoryFs.files, name)
+		}
+	}
+	for name, file := range moved {
+		memoryFs.files[name] = file
+	}
+
+	if len(moved) == 0 {
+		return &fs.PathError{Op: "rename", Path: oldPath, Err: fs.ErrNotExist}
+	}
+	return nil
+}
+
+func (memoryFs *MemoryFileSystem) RemoveAll(filePath string) error {
+	name := memoryPath(filePath)
+
+	memoryFs.mutex.Lock()
+	defer memoryFs.mutex.Unlock()
+	for file := range memoryFs.files {
+		if name == "." || file == name || strings.HasPrefix(file, name+"/") {
+			delete(memoryFs.files, file)
+		}
+	}
+	return nil
+}
+
+// memoryFile buffers the content of a created file, saved in the file system when it is closed
+type memoryFile struct {
+	bytes.Buffer
+	fileSystem *MemoryFileSystem
+	path       string
+	perm       uint32
+}
+
+func (file *memoryFile) Close() error {
+	return file.fileSystem.WriteFile(file.path, file.Bytes(), file.perm)
+}
+
+// readPath converts the separators of an OS path, the other invalid names being refused by fs.FS
+func readPath(name string) string {
+	return filepath.ToSlash(name)
+}
+
+// memoryPath converts an OS path into a slash-separated path relative to the root
+func memoryPath(filePath string) string {
+	name := path.Clean(filepath.ToSlash(filePath))
+	name = strings.TrimPrefix(name, "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
@@ -0,0 +1,52 @@
+package system
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryFileSystem(t *testing.T) {
+	memoryFs := NewMemoryFileSystem()
+
+	assert.NoError(t, memoryFs.MkdirAll("doc/html", 0755))
+	assert.NoError(t, memoryFs.WriteFile("doc/html/index.html", []byte("<html>"), 0644))
+	assert.NoError(t, memoryFs.WriteFile("/internal/parser/parser.go", []byte("package parser"), 0644))
+
+	assert.True(t, memoryFs.FileExists("doc/html"))
+	assert.True(t, memoryFs.FileExists("./internal/parser/parser.go"))
+	assert.False(t, memoryFs.FileExists("doc/json"))
+
+	content, err := memoryFs.ReadFile("internal/parser/parser.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package parser", string(content))
+	content, err = memoryFs.ReadFile(filepath.Join("internal", "parser", "parser.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package parser", string(content))
+	_, err = memoryFs.ReadFile("doc/json")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	entries, err := fs.ReadDir(memoryFs, "internal")
+	assert.NoError(t, err)
+	assert.Equal(t, "parser", entries[0].Name())
+	assert.True(t, entries[0].IsDir())
+
+	assert.NoError(t, memoryFs.Rename("doc/html", "doc/site"))
+	assert.True(t, memoryFs.FileExists("doc/site/index.html"))
+	assert.False(t, memoryFs.FileExists("doc/html/index.html"))
+	assert.ErrorIs(t, memoryFs.Rename("doc/html", "doc/old"), fs.ErrNotExist)
+
+	assert.Error(t, memoryFs.WriteFile("doc/site", []byte{}, 0644))
+	assert.Error(t, memoryFs.MkdirAll("internal/parser/parser.go/dir", 0755))
+
+	assert.NoError(t, fstest.TestFS(memoryFs, "doc/site/index.html", "internal/parser/parser.go"))
+
+	assert.NoError(t, memoryFs.RemoveAll("doc"))
+	assert.False(t, memoryFs.FileExists("doc/site/index.html"))
+	assert.False(t, memoryFs.FileExists("doc"))
+	assert.True(t, memoryFs.FileExists("internal/parser/parser.go"))
+	assert.NoError(t, memoryFs.RemoveAll("doc"))
+}
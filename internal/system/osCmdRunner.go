@@ -1,15 +1,82 @@
 package system
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"strings"
+	"time"
 )
 
+// delay given to a cancelled command to release its output before it is abandoned
+const COMMAND_WAIT_DELAY = 5 * time.Second
+
+type commandTimeoutKey struct{}
+
 type OSCommandRunner struct {
 	CommandRunner
 }
 
-func (r OSCommandRunner) Execute(dir string, name string, args ...string) ([]byte, error) {
-	cmd := exec.Command(name, args...)
+/*
+@description Set the timeout of the external commands run with the given context. Each command is stopped when it runs longer than the timeout.
+@param ctx context.Context - The parent context
+@param timeout time.Duration - The timeout of a command, 0 to disable it
+@return context.Context - The context carrying the timeout
+@example WithCommandTimeout(ctx, 10*time.Minute)
+@author Dorian TERBAH
+*/
+func WithCommandTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, commandTimeoutKey{}, timeout)
+}
+
+func (r OSCommandRunner) Execute(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	ctx, cancel := commandContext(ctx)
+	defer cancel()
+
+	cmd := newCommand(ctx, dir, name, args...)
+	output, err := cmd.CombinedOutput()
+	return output, commandError(ctx, name, args, err)
+}
+
+func (r OSCommandRunner) ExecuteWithInput(ctx context.Context, dir string, input []byte, name string, args ...string) ([]byte, error) {
+	ctx, cancel := commandContext(ctx)
+	defer cancel()
+
+	cmd := newCommand(ctx, dir, name, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	return output, commandError(ctx, name, args, err)
+}
+
+// commandContext applies the command timeout carried by the context
+func commandContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout, ok := ctx.Value(commandTimeoutKey{}).(time.Duration); ok && timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func newCommand(ctx context.Context, dir string, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = dir
-	return cmd.CombinedOutput()
+	// children keeping the output open, such as the scripts of npm, must not block a cancelled command
+	cmd.WaitDelay = COMMAND_WAIT_DELAY
+	return cmd
+}
+
+// commandError explains why a command was stopped by its context
+func commandError(ctx context.Context, name string, args []string, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	command := strings.TrimSpace(name + " " + strings.Join(args, " "))
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("command \"%s\" timed out: %w", command, ctx.Err())
+	}
+	return fmt.Errorf("command \"%s\" stopped: %w", command, ctx.Err())
 }
@@ -1,11 +1,13 @@
 package system
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -13,7 +15,7 @@ import (
 func TestOSCommandRunner_Execute_Success(t *testing.T) {
 	runner := OSCommandRunner{}
 
-	output, err := runner.Execute("", "echo", "hello world")
+	output, err := runner.Execute(context.Background(), "", "echo", "hello world")
 	assert.NoError(t, err)
 	assert.Contains(t, string(output), "hello world")
 }
@@ -21,11 +23,23 @@ func TestOSCommandRunner_Execute_Success(t *testing.T) {
 func TestOSCommandRunner_Execute_Failure(t *testing.T) {
 	runner := OSCommandRunner{}
 
-	output, err := runner.Execute("", "fakecommand123")
+	output, err := runner.Execute(context.Background(), "", "fakecommand123")
 	assert.Error(t, err)
 	assert.Empty(t, output)
 }
 
+func TestOSCommandRunner_ExecuteWithInput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("cat is not available on windows")
+	}
+
+	runner := OSCommandRunner{}
+	output, err := runner.ExecuteWithInput(context.Background(), "", []byte("hello stdin"), "cat")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello stdin", string(output))
+}
+
 func TestOSCommandRunner_Execute_WithWorkingDir(t *testing.T) {
 	runner := OSCommandRunner{}
 
@@ -34,9 +48,9 @@ func TestOSCommandRunner_Execute_WithWorkingDir(t *testing.T) {
 	var output []byte
 	var err error
 	if runtime.GOOS == "windows" {
-		output, err = runner.Execute(tempDir, "cmd", "/C", "cd")
+		output, err = runner.Execute(context.Background(), tempDir, "cmd", "/C", "cd")
 	} else {
-		output, err = runner.Execute(tempDir, "pwd")
+		output, err = runner.Execute(context.Background(), tempDir, "pwd")
 	}
 
 	assert.NoError(t, err)
@@ -48,3 +62,31 @@ func TestOSCommandRunner_Execute_WithWorkingDir(t *testing.T) {
 
 	assert.Contains(t, normalizedOutput, normalizedTempDir)
 }
+
+func TestOSCommandRunner_Execute_Timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sleep is not available on windows")
+	}
+
+	runner := OSCommandRunner{}
+	ctx := WithCommandTimeout(context.Background(), 50*time.Millisecond)
+
+	start := time.Now()
+	_, err := runner.Execute(ctx, "", "sleep", "5")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "command \"sleep 5\" timed out")
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// the timeout applies to each command, not to the context
+	_, err = runner.Execute(ctx, "", "echo", "hello")
+	assert.NoError(t, err)
+}
+
+func TestOSCommandRunner_Execute_Cancelled(t *testing.T) {
+	runner := OSCommandRunner{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := runner.ExecuteWithInput(ctx, "", []byte("hello"), "cat")
+	assert.ErrorIs(t, err, context.Canceled)
+}
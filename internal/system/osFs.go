@@ -1,6 +1,7 @@
 package system
 
 import (
+	"io"
 	"io/fs"
 	"os"
 )
@@ -14,10 +15,22 @@ func (fs OSFileSystem) FileExists(path string) bool {
 	return err == nil
 }
 
+func (fs OSFileSystem) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (fs OSFileSystem) Open(path string) (fs.File, error) {
+	return os.Open(path)
+}
+
 func (fs OSFileSystem) WriteFile(path string, data []byte, perm uint32) error {
 	return os.WriteFile(path, data, os.FileMode(perm))
 }
 
+func (fs OSFileSystem) Create(path string, perm uint32) (io.WriteCloser, error) {
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(perm))
+}
+
 func (fs OSFileSystem) MkdirAll(path string, perm fs.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
@@ -25,3 +38,7 @@ func (fs OSFileSystem) MkdirAll(path string, perm fs.FileMode) error {
 func (fs OSFileSystem) Rename(oldPath, newPath string) error {
 	return os.Rename(oldPath, newPath)
 }
+
+func (fs OSFileSystem) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
@@ -2,6 +2,7 @@ package system
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,3 +69,16 @@ func TestOSFileSystem_Rename(t *testing.T) {
 	_, err = os.Stat(oldFilePath)
 	assert.True(t, os.IsNotExist(err))
 }
+
+func TestOSFileSystem_RemoveAll(t *testing.T) {
+	fs := OSFileSystem{}
+
+	dir := filepath.Join(t.TempDir(), "doc")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "html"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "html", "index.html"), []byte("<html></html>"), 0644))
+
+	assert.NoError(t, fs.RemoveAll(dir))
+	_, err := os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, fs.RemoveAll(dir))
+}
@@ -0,0 +1,248 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Suffixes of the temporary files written next to their target during a transaction
+const (
+	TRANSACTION_STAGED_SUFFIX = ".zendoc-tmp"
+	TRANSACTION_BACKUP_SUFFIX = ".zendoc-bak"
+)
+
+/*
+@description Set of file writes and removals applied together. Each write is staged in a temporary file next to its target, and the commit renames the staged files into place in the order they were staged, the removed files being moved aside until the end of the commit. If a rename fails, the files already committed are restored, so the targets are either all updated or all left untouched. The folders created for the staged files are removed when the transaction is rolled back.
+@author Dorian TERBAH
+*/
+type Transaction struct {
+	fileSystem  FileSystem
+	staged      []stagedFile
+	createdDirs []string
+}
+
+// stagedFile is a target of the transaction, written with its staged content or removed
+type stagedFile struct {
+	path   string
+	remove bool
+}
+
+/*
+@description Create an empty transaction writing through the given file system
+@param fileSystem FileSystem - The file system holding the files
+@return *Transaction - The transaction
+@example NewTransaction(OSFileSystem{})
+@author Dorian TERBAH
+*/
+func NewTransaction(fileSystem FileSystem) *Transaction {
+	return &Transaction{fileSystem: fileSystem}
+}
+
+/*
+@description Check if a file is a temporary file of a transaction, such as a staged content or the backup of a replaced file
+@param path string - The path of the file
+@return bool - true if the file belongs to a transaction in progress
+@example IsTransactionFile("doc/.app.json.zendoc-tmp") => true
+@author Dorian TERBAH
+*/
+func IsTransactionFile(path string) bool {
+	return strings.HasSuffix(path, TRANSACTION_STAGED_SUFFIX) || strings.HasSuffix(path, TRANSACTION_BACKUP_SUFFIX)
+}
+
+/*
+@description Create a folder and its missing parents, so that files can be staged in it. The folders created are removed if the transaction is rolled back.
+@param path string - The path of the folder
+@param perm fs.FileMode - The permissions of the created folders
+@return error - An error if a folder cannot be created
+@example transaction.MkdirAll("doc/1.0.0/pages", os.ModePerm)
+@author Dorian TERBAH
+*/
+func (transaction *Transaction) MkdirAll(path string, perm fs.FileMode) error {
+	missing := []string{}
+	for dir := filepath.Clean(path); !transaction.fileSystem.FileExists(dir); dir = filepath.Dir(dir) {
+		missing = append(missing, dir)
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := transaction.fileSystem.MkdirAll(path, perm); err != nil {
+		return err
+	}
+	// the parents first, so that the rollback removes the deepest folders first
+	for i := len(missing) - 1; i >= 0; i-- {
+		transaction.createdDirs = append(transaction.createdDirs, missing[i])
+	}
+	return nil
+}
+
+/*
+@description Stage the content of a file. The target is only written by the commit, and writing the same target again replaces its staged content.
+@param path string - The path of the target file, whose folder must exist
+@param data []byte - The content of the file
+@param perm uint32 - The permissions of the file
+@return error - An error if the staged file cannot be written
+@author Dorian TERBAH
+*/
+func (transaction *Transaction) WriteFile(path string, data []byte, perm uint32) error {
+	if err := transaction.fileSystem.WriteFile(stagedPath(path), data, perm); err != nil {
+		return err
+	}
+
+	transaction.stage(path, false)
+	return nil
+}
+
+/*
+@description Stage the removal of a file or a folder. The target is only removed by the commit, and removing a target that does not exist is not an error.
+@param path string - The path of the target
+@author Dorian TERBAH
+*/
+func (transaction *Transaction) Remove(path string) {
+	transaction.fileSystem.RemoveAll(stagedPath(path))
+	transaction.stage(path, true)
+}
+
+/*
+@description Stage the move of a file. Its content is staged at the new path and the old path is removed by the commit, so the file must fit in memory.
+@param oldPath string - The path of the file to move
+@param newPath string - The new path of the file, whose folder must exist
+@return error - An error if the file cannot be read, matching fs.ErrNotExist when it does not exist, or if its new content cannot be staged
+@author Dorian TERBAH
+*/
+func (transaction *Transaction) Rename(oldPath, newPath string) error {
+	file, err := transaction.fileSystem.Open(oldPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	if err := transaction.WriteFile(newPath, content, uint32(info.Mode().Perm())); err != nil {
+		return err
+	}
+	transaction.Remove(oldPath)
+	return nil
+}
+
+/*
+@description Stage a file written progressively, for contents too large to be held in memory. The writer must be closed before the commit.
+@param path string - The path of the target file, whose folder must exist
+@param perm uint32 - The permissions of the file
+@return (io.WriteCloser, error) - The writer of the staged file and an error if it cannot be created
+@author Dorian TERBAH
+*/
+func (transaction *Transaction) Create(path string, perm uint32) (io.WriteCloser, error) {
+	writer, err := transaction.fileSystem.Create(stagedPath(path), perm)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.stage(path, false)
+	return writer, nil
+}
+
+/*
+@description Move the staged files into place and remove the removed ones. When a file cannot be moved, the committed files are restored and the staged files are removed.
+@return error - An error if a staged file cannot be moved into place or a removed file cannot be moved aside
+@author Dorian TERBAH
+*/
+func (transaction *Transaction) Commit() error {
+	committed := []string{}
+	backups := map[string]bool{}
+
+	for _, file := range transaction.staged {
+		path := file.path
+		if transaction.fileSystem.FileExists(path) {
+			if err := transaction.fileSystem.Rename(path, backupPath(path)); err != nil {
+				transaction.restore(committed, backups)
+				return fmt.Errorf("error when replacing %s: %w", path, err)
+			}
+			backups[path] = true
+		}
+
+		if file.remove {
+			committed = append(committed, path)
+			continue
+		}
+
+		if err := transaction.fileSystem.Rename(stagedPath(path), path); err != nil {
+			if backups[path] {
+				transaction.fileSystem.Rename(backupPath(path), path)
+			}
+			transaction.restore(committed, backups)
+			return fmt.Errorf("error when writing %s: %w", path, err)
+		}
+		committed = append(committed, path)
+	}
+
+	for path := range backups {
+		transaction.fileSystem.RemoveAll(backupPath(path))
+	}
+	transaction.staged = nil
+	transaction.createdDirs = nil
+	return nil
+}
+
+/*
+@description Discard the staged files and the folders created for them, leaving the targets untouched. Rolling back a committed transaction does nothing.
+@author Dorian TERBAH
+*/
+func (transaction *Transaction) Rollback() {
+	for _, file := range transaction.staged {
+		if !file.remove {
+			transaction.fileSystem.RemoveAll(stagedPath(file.path))
+		}
+	}
+	for i := len(transaction.createdDirs) - 1; i >= 0; i-- {
+		transaction.fileSystem.RemoveAll(transaction.createdDirs[i])
+	}
+	transaction.staged = nil
+	transaction.createdDirs = nil
+}
+
+// restore puts back the previous content of the committed files, the last committed first, and discards the staged files
+func (transaction *Transaction) restore(committed []string, backups map[string]bool) {
+	for i := len(committed) - 1; i >= 0; i-- {
+		path := committed[i]
+		if backups[path] {
+			transaction.fileSystem.Rename(backupPath(path), path)
+		} else {
+			transaction.fileSystem.RemoveAll(path)
+		}
+	}
+	transaction.Rollback()
+}
+
+// stage records a target once, in the order of its first staging, the last staging deciding whether it is written or removed
+func (transaction *Transaction) stage(path string, remove bool) {
+	for i, staged := range transaction.staged {
+		if staged.path == path {
+			transaction.staged[i].remove = remove
+			return
+		}
+	}
+	transaction.staged = append(transaction.staged, stagedFile{path: path, remove: remove})
+}
+
+func stagedPath(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+TRANSACTION_STAGED_SUFFIX)
+}
+
+func backupPath(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+TRANSACTION_BACKUP_SUFFIX)
+}
@@ -0,0 +1,176 @@
+package system
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingFileSystem fails to move the staged content of a given file into place
+type failingFileSystem struct {
+	*MemoryFileSystem
+	failOn string
+}
+
+func (f failingFileSystem) Rename(oldPath, newPath string) error {
+	if oldPath == stagedPath(f.failOn) {
+		return errors.New("disk full")
+	}
+	return f.MemoryFileSystem.Rename(oldPath, newPath)
+}
+
+func readString(t *testing.T, memoryFs *MemoryFileSystem, name string) string {
+	content, err := memoryFs.ReadFile(name)
+	assert.NoError(t, err)
+	return string(content)
+}
+
+func TestTransaction_Commit(t *testing.T) {
+	memoryFs := NewMemoryFileSystem()
+	assert.NoError(t, memoryFs.WriteFile("doc/app.json", []byte("old"), 0644))
+
+	transaction := NewTransaction(memoryFs)
+	assert.NoError(t, transaction.WriteFile("doc/doc-1.0.0.json", []byte("doc"), 0644))
+	assert.NoError(t, transaction.WriteFile("doc/app.json", []byte("first"), 0644))
+	assert.NoError(t, transaction.WriteFile("doc/app.json", []byte("new"), 0644))
+
+	// nothing is visible before the commit
+	assert.False(t, memoryFs.FileExists("doc/doc-1.0.0.json"))
+	assert.Equal(t, "old", readString(t, memoryFs, "doc/app.json"))
+	assert.True(t, memoryFs.FileExists("doc/.app.json"+TRANSACTION_STAGED_SUFFIX))
+
+	assert.NoError(t, transaction.Commit())
+	assert.Equal(t, "doc", readString(t, memoryFs, "doc/doc-1.0.0.json"))
+	assert.Equal(t, "new", readString(t, memoryFs, "doc/app.json"))
+
+	entries, err := memoryFs.files.ReadDir("doc")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestTransaction_CommitFailure(t *testing.T) {
+	memoryFs := NewMemoryFileSystem()
+	assert.NoError(t, memoryFs.WriteFile("doc/app.json", []byte("old"), 0644))
+	assert.NoError(t, memoryFs.WriteFile("doc/.env", []byte("old env"), 0644))
+
+	transaction := NewTransaction(failingFileSystem{MemoryFileSystem: memoryFs, failOn: "doc/.env"})
+	assert.NoError(t, transaction.WriteFile("doc/doc-1.0.0.json", []byte("doc"), 0644))
+	assert.NoError(t, transaction.WriteFile("doc/app.json", []byte("new"), 0644))
+	assert.NoError(t, transaction.WriteFile("doc/.env", []byte("new env"), 0644))
+
+	assert.ErrorContains(t, transaction.Commit(), "disk full")
+
+	// the committed files are restored, and no temporary file is left
+	assert.Equal(t, "old", readString(t, memoryFs, "doc/app.json"))
+	assert.Equal(t, "old env", readString(t, memoryFs, "doc/.env"))
+	assert.False(t, memoryFs.FileExists("doc/doc-1.0.0.json"))
+	entries, err := memoryFs.files.ReadDir("doc")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestTransaction_RemoveAndRename(t *testing.T) {
+	memoryFs := NewMemoryFileSystem()
+	assert.NoError(t, memoryFs.WriteFile("doc/doc-1.0.0.json", []byte("old doc"), 0644))
+	assert.NoError(t, memoryFs.WriteFile("doc/doc-1.1.0.json", []byte("doc"), 0600))
+	assert.NoError(t, memoryFs.WriteFile("doc/app.json", []byte("old"), 0644))
+
+	transaction := NewTransaction(memoryFs)
+	transaction.Remove("doc/doc-1.0.0.json")
+	transaction.Remove("doc/doc-0.1.0.json")
+	assert.NoError(t, transaction.Rename("doc/doc-1.1.0.json", "doc/doc-1.1.1.json"))
+	assert.ErrorIs(t, transaction.Rename("doc/doc-0.1.0.json", "doc/doc-0.1.1.json"), fs.ErrNotExist)
+	assert.NoError(t, transaction.WriteFile("doc/app.json", []byte("new"), 0644))
+
+	// nothing is visible before the commit
+	assert.True(t, memoryFs.FileExists("doc/doc-1.0.0.json"))
+	assert.True(t, memoryFs.FileExists("doc/doc-1.1.0.json"))
+	assert.False(t, memoryFs.FileExists("doc/doc-1.1.1.json"))
+
+	assert.NoError(t, transaction.Commit())
+	assert.False(t, memoryFs.FileExists("doc/doc-1.0.0.json"))
+	assert.False(t, memoryFs.FileExists("doc/doc-1.1.0.json"))
+	assert.Equal(t, "doc", readString(t, memoryFs, "doc/doc-1.1.1.json"))
+	assert.Equal(t, fs.FileMode(0600), memoryFs.files["doc/doc-1.1.1.json"].Mode)
+	assert.Equal(t, "new", readString(t, memoryFs, "doc/app.json"))
+
+	entries, err := memoryFs.files.ReadDir("doc")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestTransaction_RemoveFailure(t *testing.T) {
+	memoryFs := NewMemoryFileSystem()
+	assert.NoError(t, memoryFs.WriteFile("doc/doc-1.0.0.json", []byte("doc"), 0644))
+	assert.NoError(t, memoryFs.WriteFile("doc/app.json", []byte("old"), 0644))
+
+	transaction := NewTransaction(failingFileSystem{MemoryFileSystem: memoryFs, failOn: "doc/app.json"})
+	transaction.Remove("doc/doc-1.0.0.json")
+	assert.NoError(t, transaction.WriteFile("doc/app.json", []byte("new"), 0644))
+
+	assert.ErrorContains(t, transaction.Commit(), "disk full")
+
+	// the removed file is put back with the previous app.json
+	assert.Equal(t, "doc", readString(t, memoryFs, "doc/doc-1.0.0.json"))
+	assert.Equal(t, "old", readString(t, memoryFs, "doc/app.json"))
+	entries, err := memoryFs.files.ReadDir("doc")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestTransaction_Create(t *testing.T) {
+	memoryFs := NewMemoryFileSystem()
+	transaction := NewTransaction(memoryFs)
+
+	writer, err := transaction.Create("doc.jsonl", 0644)
+	assert.NoError(t, err)
+	_, err = writer.Write([]byte("{}\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	assert.False(t, memoryFs.FileExists("doc.jsonl"))
+
+	assert.NoError(t, transaction.Commit())
+	assert.Equal(t, "{}\n", readString(t, memoryFs, "doc.jsonl"))
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	memoryFs := NewMemoryFileSystem()
+	transaction := NewTransaction(memoryFs)
+	assert.NoError(t, transaction.WriteFile("doc.json", []byte("doc"), 0644))
+
+	transaction.Rollback()
+	assert.False(t, memoryFs.FileExists("doc.json"))
+	assert.False(t, memoryFs.FileExists(".doc.json"+TRANSACTION_STAGED_SUFFIX))
+	assert.NoError(t, transaction.Commit())
+	assert.False(t, memoryFs.FileExists("doc.json"))
+}
+
+func TestTransaction_MkdirAll(t *testing.T) {
+	memoryFs := NewMemoryFileSystem()
+	assert.NoError(t, memoryFs.WriteFile("doc/app.json", []byte("old"), 0644))
+
+	transaction := NewTransaction(memoryFs)
+	assert.NoError(t, transaction.MkdirAll("doc/1.0.0/pages", 0755))
+	assert.NoError(t, transaction.WriteFile("doc/1.0.0/pages/index.html", []byte("page"), 0644))
+	assert.True(t, memoryFs.FileExists("doc/1.0.0/pages"))
+
+	// the created folders are removed, the existing one is kept
+	transaction.Rollback()
+	assert.False(t, memoryFs.FileExists("doc/1.0.0"))
+	assert.Equal(t, "old", readString(t, memoryFs, "doc/app.json"))
+
+	transaction = NewTransaction(memoryFs)
+	assert.NoError(t, transaction.MkdirAll("doc/1.0.0", 0755))
+	assert.NoError(t, transaction.WriteFile("doc/1.0.0/index.html", []byte("page"), 0644))
+	assert.NoError(t, transaction.Commit())
+	transaction.Rollback()
+	assert.Equal(t, "page", readString(t, memoryFs, "doc/1.0.0/index.html"))
+}
+
+func TestIsTransactionFile(t *testing.T) {
+	assert.True(t, IsTransactionFile("doc/.app.json"+TRANSACTION_STAGED_SUFFIX))
+	assert.True(t, IsTransactionFile(".doc.json"+TRANSACTION_BACKUP_SUFFIX))
+	assert.False(t, IsTransactionFile("doc.json"))
+}
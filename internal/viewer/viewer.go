@@ -0,0 +1,214 @@
+package viewer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/export/helper"
+	"github.com/dterbah/zendoc/internal/search"
+	"github.com/fatih/color"
+)
+
+/*
+@description Struct to represent a symbol printed with the --json flag
+@author Dorian TERBAH
+@field ID string - The identifier of the symbol
+@field Name string - The qualified name of the symbol
+@field Package string - The package declaring the symbol
+@field File string - The path of the file declaring the symbol
+@field Line int - The line of the declaration
+@field Signature string - The Go signature of the symbol
+@field Item doc.DocItem - The documented item
+*/
+type JSONSymbol struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Package   string      `json:"package"`
+	File      string      `json:"file"`
+	Line      int         `json:"line"`
+	Signature string      `json:"signature"`
+	Item      doc.DocItem `json:"item"`
+}
+
+/*
+@description Error returned when a query matches several symbols equally well
+@author Dorian TERBAH
+@field Query string - The ambiguous query
+@field Candidates []doc.Symbol - The matching symbols
+*/
+type AmbiguousError struct {
+	Query      string
+	Candidates []doc.Symbol
+}
+
+func (err AmbiguousError) Error() string {
+	ids := []string{}
+	for _, candidate := range err.Candidates {
+		ids = append(ids, candidate.ID)
+	}
+	return fmt.Sprintf("\"%s\" matches several symbols: %s", err.Query, strings.Join(ids, ", "))
+}
+
+/*
+@description Find the symbol matching a query: its identifier (package.Name or package.Struct.Method), its qualified name, its name, or else the best hit of a full-text search
+@param projectDoc doc.ProjectDoc - The project documentation
+@param query string - The query
+@return (doc.Symbol, error) - The symbol, and an AmbiguousError if several symbols have this name, or an error if nothing matches
+@example Find(projectDoc, "parser.DocParser.ParseDocForDir")
+@author Dorian TERBAH
+*/
+func Find(projectDoc doc.ProjectDoc, query string) (doc.Symbol, error) {
+	symbols := doc.Symbols(projectDoc)
+
+	if symbol, ok := doc.FindSymbol(symbols, query); ok {
+		return symbol, nil
+	}
+
+	for _, matches := range []func(symbol doc.Symbol) bool{
+		func(symbol doc.Symbol) bool { return strings.EqualFold(symbol.Name, query) },
+		func(symbol doc.Symbol) bool { return strings.EqualFold(symbol.Item.GetBaseDoc().Name, query) },
+	} {
+		candidates := []doc.Symbol{}
+		for _, symbol := range symbols {
+			if matches(symbol) {
+				candidates = append(candidates, symbol)
+			}
+		}
+
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return candidates[0], nil
+		default:
+			return doc.Symbol{}, AmbiguousError{Query: query, Candidates: candidates}
+		}
+	}
+
+	hits := search.Build(projectDoc).Search(query, 1)
+	if len(hits) == 0 {
+		return doc.Symbol{}, fmt.Errorf("no symbol matches \"%s\"", query)
+	}
+
+	symbol, _ := doc.FindSymbol(symbols, hits[0].Document.ID)
+	return symbol, nil
+}
+
+/*
+@description Print the documentation of a symbol as JSON
+@param writer io.Writer - The output
+@param symbol doc.Symbol - The symbol to print
+@return error - An error if the symbol cannot be serialized
+@author Dorian TERBAH
+*/
+func PrintJSON(writer io.Writer, symbol doc.Symbol) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(JSONSymbol{
+		ID:        symbol.ID,
+		Name:      symbol.Name,
+		Package:   symbol.Package,
+		File:      symbol.File.Path,
+		Line:      symbol.Item.GetBaseDoc().Position.StartLine,
+		Signature: helper.Signature(symbol.Item),
+		Item:      symbol.Item,
+	})
+}
+
+var (
+	titleColor   = color.New(color.Bold, color.FgHiWhite)
+	sectionColor = color.New(color.Bold, color.FgCyan)
+	codeColor    = color.New(color.FgGreen)
+	mutedColor   = color.New(color.FgHiBlack)
+	warnColor    = color.New(color.Bold, color.FgYellow)
+)
+
+/*
+@description Print the documentation of a symbol for the terminal: signature, description, params, return, fields, methods, example, deprecation and source location
+@param writer io.Writer - The output
+@param symbol doc.Symbol - The symbol to print
+@author Dorian TERBAH
+*/
+func Print(writer io.Writer, symbol doc.Symbol) {
+	baseDoc := symbol.Item.GetBaseDoc()
+
+	fmt.Fprintf(writer, "%s %s\n", titleColor.Sprint(symbol.ID), mutedColor.Sprintf("(%s)", baseDoc.Type))
+	location := fmt.Sprintf("%s:%d", symbol.File.Path, baseDoc.Position.StartLine)
+	if baseDoc.SourceLink != "" {
+		location += "  " + baseDoc.SourceLink
+	}
+	fmt.Fprintln(writer, mutedColor.Sprint(location))
+	fmt.Fprintln(writer)
+
+	for _, line := range strings.Split(helper.Signature(symbol.Item), "\n") {
+		fmt.Fprintf(writer, "    %s\n", codeColor.Sprint(line))
+	}
+
+	if baseDoc.Deprecated != "" {
+		fmt.Fprintf(writer, "\n%s %s\n", warnColor.Sprint("DEPRECATED:"), baseDoc.Deprecated)
+	}
+	if baseDoc.Description != "" {
+		fmt.Fprintf(writer, "\n%s\n", baseDoc.Description)
+	}
+
+	switch item := symbol.Item.(type) {
+	case *doc.FuncDoc:
+		printParams(writer, "PARAMS", item.Params)
+		if item.Return != nil && item.Return.Type != "" {
+			printSection(writer, "RETURNS")
+			printTable(writer, [][]string{{item.Return.Type, item.Return.Description}})
+		}
+		if item.Example != "" {
+			printSection(writer, "EXAMPLE")
+			fmt.Fprintf(writer, "  %s\n", codeColor.Sprint(item.Example))
+		}
+	case *doc.StructDoc:
+		printParams(writer, "FIELDS", item.Fields)
+	case *doc.InterfaceDoc:
+		if len(item.Methods) > 0 {
+			rows := [][]string{}
+			for _, method := range item.Methods {
+				rows = append(rows, []string{method.Name, method.Description})
+			}
+			printSection(writer, "METHODS")
+			printTable(writer, rows)
+		}
+	}
+
+	if baseDoc.Author != "" {
+		printSection(writer, "AUTHOR")
+		fmt.Fprintf(writer, "  %s\n", baseDoc.Author)
+	}
+}
+
+func printSection(writer io.Writer, title string) {
+	fmt.Fprintf(writer, "\n%s\n", sectionColor.Sprint(title))
+}
+
+func printParams(writer io.Writer, title string, params []doc.Param) {
+	if len(params) == 0 {
+		return
+	}
+
+	rows := [][]string{}
+	for _, param := range params {
+		rows = append(rows, []string{param.Name, param.Type, param.Description})
+	}
+	printSection(writer, title)
+	printTable(writer, rows)
+}
+
+// printTable aligns the columns of the rows, without colors so that escape codes do not shift them
+func printTable(writer io.Writer, rows [][]string) {
+	table := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintf(table, "  %s\n", strings.Join(row, "\t"))
+	}
+	table.Flush()
+}
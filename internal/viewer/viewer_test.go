@@ -0,0 +1,84 @@
+package viewer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dterbah/zendoc/internal/doc"
+	"github.com/dterbah/zendoc/internal/doc/doctest"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	projectDoc := doctest.Sample()
+
+	tests := map[string]string{
+		"parser.DocParser.ParseDocForDir": "parser.DocParser.ParseDocForDir",
+		"DocParser.ParseDocForDir":        "parser.DocParser.ParseDocForDir",
+		"parsedocfordir":                  "parser.DocParser.ParseDocForDir",
+		"docexporter":                     "export.DocExporter",
+		"parse dir":                       "parser.DocParser.ParseDocForDir",
+	}
+
+	for query, expected := range tests {
+		symbol, err := Find(projectDoc, query)
+		assert.NoError(t, err, query)
+		assert.Equal(t, expected, symbol.ID, query)
+	}
+
+	_, err := Find(projectDoc, "Export")
+	var ambiguous AmbiguousError
+	assert.ErrorAs(t, err, &ambiguous)
+	assert.Len(t, ambiguous.Candidates, 2)
+
+	_, err = Find(projectDoc, "nothing")
+	assert.ErrorContains(t, err, "no symbol matches")
+}
+
+func TestPrint(t *testing.T) {
+	color.NoColor = true
+	symbol, err := Find(doctest.Sample(), "ParseDocForDir")
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	Print(&buf, symbol)
+	output := buf.String()
+
+	assert.Contains(t, output, "parser.DocParser.ParseDocForDir (function)\n")
+	assert.Contains(t, output, "internal/parser/parser.go:74  https://github.com/a/b/blob/main/internal/parser/parser.go#L74-L123\n")
+	assert.Contains(t, output, "    func (DocParser) ParseDocForDir(dirPath string) (*doc.ProjectDoc, error)\n")
+	assert.Contains(t, output, "DEPRECATED: Use ParseFS\n")
+	assert.Contains(t, output, "PARAMS\n  dirPath  string  The root | path\n")
+	assert.Contains(t, output, "RETURNS\n  (*doc.ProjectDoc, error)  The documentation\n")
+	assert.Contains(t, output, "EXAMPLE\n  ParseDocForDir(\"./myproject\", \"\")\n")
+	assert.Contains(t, output, "AUTHOR\n  Dorian TERBAH\n")
+
+	buf.Reset()
+	symbol, _ = Find(doctest.Sample(), "DocExporter")
+	Print(&buf, symbol)
+	assert.Contains(t, buf.String(), "METHODS\n  Export  Export the documentation\n")
+}
+
+func TestPrintJSON(t *testing.T) {
+	symbol, err := Find(doctest.Sample(), "DocParser")
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, PrintJSON(&buf, symbol))
+
+	var printed struct {
+		ID        string        `json:"id"`
+		File      string        `json:"file"`
+		Line      int           `json:"line"`
+		Signature string        `json:"signature"`
+		Item      doc.StructDoc `json:"item"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &printed))
+	assert.Equal(t, "parser.DocParser", printed.ID)
+	assert.Equal(t, "internal/parser/parser.go", printed.File)
+	assert.Equal(t, 29, printed.Line)
+	assert.Equal(t, "type DocParser struct {\n\tFileValidators []DocParserFileValidator\n}", printed.Signature)
+	assert.Equal(t, "FileValidators", printed.Item.Fields[0].Name)
+}
@@ -0,0 +1,84 @@
+package zendoc
+
+import (
+	"io"
+
+	"github.com/dterbah/zendoc/internal/doc"
+)
+
+// Version of the serialized documentation format
+const SchemaVersion = doc.SCHEMA_VERSION
+
+// Types of the documented items, stored in BaseDoc.Type
+const (
+	FunctionType        = doc.FUNCTION_TYPE
+	StructType          = doc.STRUCT_TYPE
+	InterfaceType       = doc.INTERFACE_TYPE
+	InterfaceMethodType = doc.INTERFACE_METHOD_TYPE
+)
+
+// ProjectDoc is the documentation of a project, grouped by package
+type ProjectDoc = doc.ProjectDoc
+
+// FileDoc is the documentation of a source file
+type FileDoc = doc.FileDoc
+
+// DocItem is implemented by every documented item: *FuncDoc, *StructDoc and *InterfaceDoc
+type DocItem = doc.DocItem
+
+// BaseDoc holds the metadata shared by all the documented items
+type BaseDoc = doc.BaseDoc
+
+// FuncDoc is the documentation of a function or of a method
+type FuncDoc = doc.FuncDoc
+
+// StructDoc is the documentation of a struct
+type StructDoc = doc.StructDoc
+
+// InterfaceDoc is the documentation of an interface and of its methods
+type InterfaceDoc = doc.InterfaceDoc
+
+// Param is a documented parameter of a function
+type Param = doc.Param
+
+// StructField is a documented field of a struct
+type StructField = doc.StructField
+
+// Return is the documented return value of a function
+type Return = doc.Return
+
+// Position is the location of a documented item in its source file
+type Position = doc.Position
+
+// Symbol is a documented item with its package, its file and its qualified identifier
+type Symbol = doc.Symbol
+
+/*
+@description Create an empty project documentation
+@return *ProjectDoc - The documentation, without any package
+@author Dorian TERBAH
+*/
+func NewProjectDoc() *ProjectDoc {
+	return doc.NewProjectDoc()
+}
+
+/*
+@description Decode a documentation exported in JSON, such as the doc.json file written by the JSON output
+@param reader io.Reader - The JSON documentation
+@return (*ProjectDoc, error) - The documentation and an error if it cannot be decoded
+@example LoadProjectDoc(file) => &ProjectDoc{...}, nil
+@author Dorian TERBAH
+*/
+func LoadProjectDoc(reader io.Reader) (*ProjectDoc, error) {
+	return doc.LoadProjectDoc(reader)
+}
+
+/*
+@description List the documented items of a project, sorted by identifier, such as "parser.DocParser.ParseDocForDir"
+@param projectDoc ProjectDoc - The documentation of the project
+@return []Symbol - The symbols of the project
+@author Dorian TERBAH
+*/
+func Symbols(projectDoc ProjectDoc) []Symbol {
+	return doc.Symbols(projectDoc)
+}
@@ -0,0 +1,128 @@
+package zendoc
+
+import (
+	"io/fs"
+
+	"github.com/dterbah/zendoc/config"
+)
+
+/*
+@description Struct to represent the settings of a parsing, changed through the options given to Parse
+@author Dorian TERBAH
+@field dir string - The directory of the project, on the OS file system
+@field fsys fs.FS - The file system of the project, used instead of the directory when set
+@field docConfig config.DocConfig - The selection of the files and functions to document
+@field progress bool - Value used to print the progress of the parsing
+*/
+type parseOptions struct {
+	dir       string
+	fsys      fs.FS
+	docConfig config.DocConfig
+	progress  bool
+}
+
+/*
+@description Option of Parse, mirroring a setting of the "docConfig" section of the ZenDoc configuration or selecting the sources to parse
+@author Dorian TERBAH
+*/
+type Option func(options *parseOptions)
+
+/*
+@description Parse the project in the given directory. The default is the current directory.
+@param dir string - The directory of the project
+@return Option - The option
+@example Parse(ctx, WithDir("./myproject"))
+@author Dorian TERBAH
+*/
+func WithDir(dir string) Option {
+	return func(options *parseOptions) {
+		options.dir = dir
+	}
+}
+
+/*
+@description Parse the project held by a file system, such as an embedded tree, a zip archive or an in-memory file system, instead of a directory
+@param fsys fs.FS - The file system, whose root is the root of the project
+@return Option - The option
+@example Parse(ctx, WithFS(os.DirFS("./myproject")))
+@author Dorian TERBAH
+*/
+func WithFS(fsys fs.FS) Option {
+	return func(options *parseOptions) {
+		options.fsys = fsys
+	}
+}
+
+/*
+@description Apply the whole "docConfig" section of a ZenDoc configuration. The options given after it override its settings.
+@param docConfig config.DocConfig - The documentation configuration
+@return Option - The option
+@example Parse(ctx, WithDocConfig(projectConfig.DocConfig))
+@author Dorian TERBAH
+*/
+func WithDocConfig(docConfig config.DocConfig) Option {
+	return func(options *parseOptions) {
+		options.docConfig = docConfig
+	}
+}
+
+/*
+@description Document the private functions, like "includePrivate" in the configuration. They are skipped by default.
+@param include bool - Value used to document the private functions
+@return Option - The option
+@author Dorian TERBAH
+*/
+func WithIncludePrivate(include bool) Option {
+	return func(options *parseOptions) {
+		options.docConfig.IncludePrivate = include
+	}
+}
+
+/*
+@description Document the test files, like "includeTests" in the configuration. They are skipped by default.
+@param include bool - Value used to document the test files
+@return Option - The option
+@author Dorian TERBAH
+*/
+func WithIncludeTests(include bool) Option {
+	return func(options *parseOptions) {
+		options.docConfig.IncludeTests = include
+	}
+}
+
+/*
+@description Document the main files, like "includeMain" in the configuration. They are skipped by default.
+@param include bool - Value used to document the main files
+@return Option - The option
+@author Dorian TERBAH
+*/
+func WithIncludeMain(include bool) Option {
+	return func(options *parseOptions) {
+		options.docConfig.IncludeMain = include
+	}
+}
+
+/*
+@description Skip the files whose name matches one of the regular expressions, like "excludeFiles" in the configuration
+@param patterns ...string - The regular expressions
+@return Option - The option
+@example Parse(ctx, WithExcludeFiles(`_gen\.go$`))
+@author Dorian TERBAH
+*/
+func WithExcludeFiles(patterns ...string) Option {
+	return func(options *parseOptions) {
+		options.docConfig.ExcludeFiles = append(options.docConfig.ExcludeFiles, patterns...)
+	}
+}
+
+/*
+@description Print the files being processed on the standard output, as the zendoc commands do. The parsing is silent by default.
+@param progress bool - Value used to print the progress
+@return Option - The option
+@author Dorian TERBAH
+*/
+func WithProgress(progress bool) Option {
+	return func(options *parseOptions) {
+		options.progress = progress
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Package zendoc is the public API of ZenDoc, to parse the documentation of a Go project and export it from another program, the way the zendoc commands do.
+
+	projectDoc, err := zendoc.Parse(ctx, zendoc.WithDir("./myproject"), zendoc.WithIncludePrivate(true))
+	if err != nil {
+		return err
+	}
+	return zendoc.Export(ctx, *projectDoc, myExporter)
+*/
+package zendoc
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dterbah/zendoc/internal/parser"
+)
+
+/*
+@description Export a project documentation in a specific format. The exporters of the zendoc commands implement it.
+@author Dorian TERBAH
+*/
+type Exporter interface {
+	/*
+		@description Export the documentation
+		@param ctx context.Context - The context of the export. An exporter writes nothing once it is done.
+		@param projectDoc ProjectDoc - The documentation to export
+		@author Dorian TERBAH
+		@return error - If there is any problem during the export
+	*/
+	Export(ctx context.Context, projectDoc ProjectDoc) error
+}
+
+/*
+@description Function implementing the Exporter interface, to export a documentation without declaring a type
+@author Dorian TERBAH
+*/
+type ExporterFunc func(ctx context.Context, projectDoc ProjectDoc) error
+
+/*
+@description Export the documentation by calling the function
+@param ctx context.Context - The context of the export
+@param projectDoc ProjectDoc - The documentation to export
+@return error - The error returned by the function
+@author Dorian TERBAH
+*/
+func (exporterFunc ExporterFunc) Export(ctx context.Context, projectDoc ProjectDoc) error {
+	return exporterFunc(ctx, projectDoc)
+}
+
+/*
+@description Parse the documentation of a Go project. Without options, the current directory is parsed, skipping the private functions, the test files and the main files.
+@param ctx context.Context - The context of the parsing, which stops it when done
+@param options ...Option - The options selecting the project and the documented files
+@return (*ProjectDoc, error) - The documentation of the project and an error if the project cannot be read or the context is done
+@example Parse(ctx, WithDir("./myproject"), WithIncludeTests(true)) => &ProjectDoc{...}, nil
+@author Dorian TERBAH
+*/
+func Parse(ctx context.Context, options ...Option) (*ProjectDoc, error) {
+	parseOptions := parseOptions{dir: "."}
+	for _, option := range options {
+		option(&parseOptions)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fsys := parseOptions.fsys
+	if fsys == nil {
+		fsys = os.DirFS(parseOptions.dir)
+	}
+
+	docParser := parser.NewDocParser(parseOptions.docConfig)
+	docParser.Quiet = !parseOptions.progress
+
+	projectDoc, err := docParser.ParseDocForFS(ctx, fsys, ".", "")
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error when parsing the project: %w", err)
+	}
+
+	return projectDoc, nil
+}
+
+/*
+@description Export a project documentation with the given exporter
+@param ctx context.Context - The context of the export, given to the exporter
+@param projectDoc ProjectDoc - The documentation to export
+@param exporter Exporter - The exporter writing the documentation
+@return error - An error if the context is done or if the export fails
+@example Export(ctx, *projectDoc, ExporterFunc(func(ctx context.Context, projectDoc ProjectDoc) error { ... }))
+@author Dorian TERBAH
+*/
+func Export(ctx context.Context, projectDoc ProjectDoc, exporter Exporter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return exporter.Export(ctx, projectDoc)
+}
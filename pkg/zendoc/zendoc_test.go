@@ -0,0 +1,99 @@
+package zendoc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dterbah/zendoc/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var project = fstest.MapFS{
+	"math/add.go": {Data: []byte(`package math
+
+// @description Adds two numbers
+func Add(a int, b int) int {
+	return a + b
+}
+
+// @description Adds without checks
+func add(a int, b int) int {
+	return a + b
+}
+`)},
+	"math/add_test.go": {Data: []byte(`package math
+
+// @description Tests the addition
+func TestAdd() {}
+`)},
+	"math/gen_sub.go": {Data: []byte(`package math
+
+// @description Subtracts two numbers
+func Sub(a int, b int) int {
+	return a - b
+}
+`)},
+}
+
+func functionNames(projectDoc *ProjectDoc) []string {
+	names := []string{}
+	for _, symbol := range Symbols(*projectDoc) {
+		names = append(names, symbol.Name)
+	}
+	return names
+}
+
+func TestParse(t *testing.T) {
+	projectDoc, err := Parse(context.Background(), WithFS(project))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Add", "Sub"}, functionNames(projectDoc))
+
+	projectDoc, err = Parse(context.Background(), WithFS(project), WithIncludePrivate(true), WithIncludeTests(true), WithExcludeFiles(`^gen_`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Add", "TestAdd", "add"}, functionNames(projectDoc))
+
+	fd := projectDoc.PackageDocs["math"][0].Docs[0].(*FuncDoc)
+	assert.Equal(t, FunctionType, fd.Type)
+	assert.Equal(t, "Adds two numbers", fd.Description)
+}
+
+func TestParse_DocConfig(t *testing.T) {
+	docConfig := config.DocConfig{IncludePrivate: true, IncludeTests: true}
+
+	projectDoc, err := Parse(context.Background(), WithFS(project), WithDocConfig(docConfig), WithIncludeTests(false))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Add", "Sub", "add"}, functionNames(projectDoc))
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse(context.Background(), WithDir("missing"))
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Parse(ctx, WithFS(project))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestExport(t *testing.T) {
+	var exported *ProjectDoc
+	exporter := ExporterFunc(func(ctx context.Context, projectDoc ProjectDoc) error {
+		exported = &projectDoc
+		return nil
+	})
+
+	projectDoc := NewProjectDoc()
+	assert.NoError(t, Export(context.Background(), *projectDoc, exporter))
+	assert.NotNil(t, exported)
+
+	failure := errors.New("export failure")
+	assert.ErrorIs(t, Export(context.Background(), *projectDoc, ExporterFunc(func(context.Context, ProjectDoc) error { return failure })), failure)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	exported = nil
+	assert.ErrorIs(t, Export(ctx, *projectDoc, exporter), context.Canceled)
+	assert.Nil(t, exported)
+}